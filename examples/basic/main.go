@@ -2,19 +2,17 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"flag"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/state"
 	"log"
-	"meow.tf/astral/arguments"
 	"meow.tf/astral/middleware"
 	"meow.tf/astral/middleware/cooldown"
 	"meow.tf/astral/router"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
 )
@@ -24,10 +22,16 @@ var (
 	flagPrefix  = flag.String("prefix", "!", "Command prefix")
 	flagAppID   = flag.Int64("appID", 0, "App ID for commands")
 	flagGuildID = flag.Int64("guildID", 0, "Guild ID for commands")
+	flagDryRun  = flag.Bool("dryRun", false, "Only report command changes")
 
 	route *router.Route
 )
 
+type rollArgs struct {
+	Count int `astral:"count,required,min=1,max=10,desc=Number of dice"`
+	Sides int `astral:"sides,choices=6|20,desc=Number of sides"`
+}
+
 func main() {
 	flag.Parse()
 
@@ -38,10 +42,30 @@ func main() {
 
 	s := state.NewWithIntents("Bot "+*flagToken, intents...)
 
-	s.AddHandler(messageCreateHandler(s))
-	s.AddHandler(interactionHandler(s))
+	route = router.New().SetErrorHandler(func(ctx *router.Context, err error) {
+		log.Println("Error in command", ctx.Command, err)
+
+		ctx.Reply("Something went wrong, please try again later.")
+	})
+
+	dispatcher := router.NewDispatcher(s, route, *flagPrefix)
+	dispatcher.MentionPrefix = true
+
+	dispatcher.NotFound = func(event interface{}, args []string) {
+		log.Println("No match for command args", args)
+	}
+
+	dispatcher.ContextError = func(event interface{}, r *router.Route, err error) {
+		log.Println("Unable to create context:", err)
+	}
 
-	route = router.New()
+	components := router.NewComponentRouter()
+
+	dispatcher.Components = components
+
+	dispatcher.Attach()
+
+	route.Help(router.HelpOptions{Components: components}).Export(true)
 
 	ping := route.On("ping", func(ctx *router.Context) {
 		ctx.Reply("pong!")
@@ -71,9 +95,9 @@ func main() {
 
 	// Test for cooldown/rate limiting middleware
 	route.Group(func(r *router.Route) {
-		reply := middleware.CatchReply("You're doing that too often! SLOW DOWN!")
-
-		r.Use(cooldown.NewWithCatch(2, time.Minute, cooldown.User, reply))
+		r.Use(cooldown.NewWithCatch(2, time.Minute, cooldown.User, func(ctx *router.Context) {
+			ctx.Reply("You're doing that too often! SLOW DOWN! " + cooldown.Error(ctx).Error())
+		}))
 
 		r.On("test", func(ctx *router.Context) {
 			ctx.Reply("REPLY!")
@@ -87,10 +111,52 @@ func main() {
 		}).Alias("alias")
 	})
 
+	// Test for deferred responses
+	route.On("slow", func(ctx *router.Context) {
+		ctx.Defer(false)
+
+		time.Sleep(5 * time.Second)
+
+		ctx.Reply("Done!")
+	}).Desc("Takes a while").Export(true)
+
+	// Test for components
+	route.On("vote", func(ctx *router.Context) {
+		ctx.ReplyComplex(router.NewReply().Text("Do you like astral?").Component(&discord.ActionRowComponent{
+			&discord.ButtonComponent{Label: "Yes", CustomID: "vote:yes", Style: discord.SuccessButtonStyle()},
+			&discord.ButtonComponent{Label: "No", CustomID: "vote:no", Style: discord.DangerButtonStyle()},
+		}))
+	}).Desc("Starts a vote").Export(true)
+
+	components.On("vote:{choice}", func(ctx *router.ComponentContext) {
+		ctx.UpdateMessage(router.NewReply().Text(ctx.User.Username + " voted " + ctx.Param("choice")))
+	})
+
+	// Test for modals
+	feedback := router.NewModal("feedback", "Send feedback").Paragraph("message", "Message")
+
+	route.On("feedback", func(ctx *router.Context) {
+		ctx.ShowModal(feedback)
+	}).Desc("Sends feedback").Export(true)
+
+	components.OnModal(feedback, func(ctx *router.ComponentContext) {
+		ctx.ReplyEphemeral("Thanks for your feedback: " + ctx.Arg("message"))
+	})
+
+	// Test for error handling
+	route.Handle("fail", func(ctx *router.Context) error {
+		return errors.New("this command always fails")
+	})
+
 	route.On("nesting", nil).On("level1 <test>", func(ctx *router.Context) {
 		ctx.Reply("Argument: " + ctx.Arg("test"))
 	})
 
+	// Test for struct arguments
+	route.OnArgs("roll", func(ctx *router.Context, args rollArgs) {
+		ctx.Replyf("Rolling %d dice with %d sides", args.Count, args.Sides)
+	}).Desc("Rolls dice")
+
 	// Test for autocomplete
 	route.On("autocomplete <test>", func(ctx *router.Context) {
 		ctx.Reply("You chose: " + ctx.Arg("test"))
@@ -120,15 +186,18 @@ func main() {
 	log.Println("Ready.")
 
 	if *flagGuildID != 0 {
-		log.Println("Registering guild commands")
+		log.Println("Syncing guild commands")
 
-		cmds, err := router.RegisterGuildCommands(route, s, discord.AppID(*flagAppID), discord.GuildID(*flagGuildID))
+		report, err := router.SyncCommands(route, s, discord.AppID(*flagAppID), router.SyncOptions{
+			GuildID: discord.GuildID(*flagGuildID),
+			DryRun:  *flagDryRun,
+		})
 
 		if err != nil {
 			log.Fatalln(err)
 		}
 
-		log.Println("Done. Commands:", len(cmds))
+		log.Println("Done. Changes:", report)
 	}
 
 	interrupt := make(chan os.Signal, 1)
@@ -137,108 +206,3 @@ func main() {
 
 	<-interrupt
 }
-
-func messageCreateHandler(s *state.State) func(evt *gateway.MessageCreateEvent) {
-	return func(evt *gateway.MessageCreateEvent) {
-		str := evt.Content
-
-		prefix := *flagPrefix
-
-		if !strings.HasPrefix(str, prefix) {
-			return
-		}
-
-		str = strings.TrimPrefix(str, prefix)
-
-		args := arguments.Parse(str)
-
-		match := route.Find(args...)
-
-		if match == nil {
-			log.Println("No match for command args", args)
-			return
-		}
-
-		idx := strings.Index(str, " ")
-
-		var argString string
-
-		if idx == -1 {
-			argString = ""
-		} else {
-			argString = strings.TrimSpace(str[idx+1:])
-		}
-
-		level := len(match.Path())
-
-		var command string
-
-		if len(args) > 1 {
-			command, args = strings.Join(args[:level], " "), args[level:]
-		} else {
-			command = str
-			args = []string{}
-		}
-
-		ctx, err := router.ContextFrom(s, evt, match, args, argString)
-
-		if err != nil {
-			log.Println("Unable to create context:", err)
-			return
-		}
-
-		ctx.Command = command
-
-		go match.Call(ctx)
-	}
-}
-
-func interactionHandler(s *state.State) func(evt *gateway.InteractionCreateEvent) {
-	return func(evt *gateway.InteractionCreateEvent) {
-		switch data := evt.Data.(type) {
-		case *discord.CommandInteraction:
-			b, _ := json.MarshalIndent(data, "", "\t")
-
-			log.Println(string(b))
-			// Find root command
-			match := route.FindInteraction(data.Name, data.Options)
-
-			if match == nil {
-				log.Println("No match for command args")
-				return
-			}
-
-			ctx, err := router.ContextFromInteraction(s, evt, match)
-
-			if err != nil {
-				log.Println("Unable to create context:", err)
-				return
-			}
-
-			go match.Call(ctx)
-		case *discord.AutocompleteInteraction:
-			// Find root command
-			match, opts := route.FindAutocomplete(data.Name, data.Options)
-
-			if match == nil {
-				log.Println("No match for command args")
-				return
-			}
-
-			ctx, err := router.ContextFromInteraction(s, evt, match)
-
-			if err != nil {
-				log.Println("Unable to create context:", err)
-				return
-			}
-
-			log.Println("Calling autocomplete")
-
-			err = match.CallAutocomplete(ctx, opts)
-
-			if err != nil {
-				log.Println("Error calling:", err)
-			}
-		}
-	}
-}
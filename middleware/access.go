@@ -0,0 +1,261 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"meow.tf/astral/router"
+	"sync"
+)
+
+// Errors
+var (
+	ErrMissingRole     = errors.New("you don't have the required role for this command")
+	ErrNotGuildOwner   = errors.New("this command can only be used by the server owner")
+	ErrNotBotOwner     = errors.New("this command can only be used by the bot owner")
+	ErrUserNotAllowed  = errors.New("you are not allowed to use this command")
+	ErrDMOnly          = errors.New("this command can only be used in direct messages")
+	ErrConditionNotMet = errors.New("condition not met")
+)
+
+// Require converts a check into middleware, calling catch with the check's error if it fails.
+// Checks can also be added with Route.Check, which hides the route from help when it fails.
+func Require(check router.Check, catch CatchFunc) router.MiddlewareFunc {
+	return func(fn router.Handler) router.Handler {
+		return func(ctx *router.Context) {
+			if err := check(ctx); err != nil {
+				Catch(ctx, catch, err)
+				return
+			}
+			fn(ctx)
+		}
+	}
+}
+
+// RequireRole requires the member to have a role
+func RequireRole(id discord.RoleID, catch CatchFunc) router.MiddlewareFunc {
+	return Require(HasAnyRole(id), catch)
+}
+
+// RequireAnyRole requires the member to have any of the roles
+func RequireAnyRole(ids []discord.RoleID, catch CatchFunc) router.MiddlewareFunc {
+	return Require(HasAnyRole(ids...), catch)
+}
+
+// RequireOwner requires the user to be the guild owner
+func RequireOwner(catch CatchFunc) router.MiddlewareFunc {
+	return Require(IsGuildOwner, catch)
+}
+
+// RequireBotOwner requires the user to own the application, or be a member of the application's team
+func RequireBotOwner(catch CatchFunc) router.MiddlewareFunc {
+	return Require(IsBotOwner(), catch)
+}
+
+// AllowUsers only allows the specified users
+func AllowUsers(ids []discord.UserID, catch CatchFunc) router.MiddlewareFunc {
+	return Require(UserIn(ids...), catch)
+}
+
+// DenyUsers stops the specified users
+func DenyUsers(ids []discord.UserID, catch CatchFunc) router.MiddlewareFunc {
+	return Require(Not(UserIn(ids...), ErrUserNotAllowed), catch)
+}
+
+// GuildOnly requires the command to be used in a guild
+func GuildOnly(catch CatchFunc) router.MiddlewareFunc {
+	return Require(InGuild, catch)
+}
+
+// DMOnly requires the command to be used in direct messages
+func DMOnly(catch CatchFunc) router.MiddlewareFunc {
+	return Require(InDM, catch)
+}
+
+// HasAnyRole checks the member has any of the roles
+func HasAnyRole(ids ...discord.RoleID) router.Check {
+	return func(ctx *router.Context) error {
+		member, err := ctx.Member()
+
+		if err != nil {
+			return err
+		}
+
+		for _, roleID := range member.RoleIDs {
+			for _, id := range ids {
+				if roleID == id {
+					return nil
+				}
+			}
+		}
+
+		return ErrMissingRole
+	}
+}
+
+// IsGuildOwner checks the user is the guild owner
+func IsGuildOwner(ctx *router.Context) error {
+	if ctx.Guild == nil {
+		return ErrGuildOnly
+	}
+
+	if ctx.Guild.OwnerID != ctx.User.ID {
+		return ErrNotGuildOwner
+	}
+
+	return nil
+}
+
+// BotOwners fetches and caches the owners of the application, or the members of its team.
+// Owners are cached per bot user, so each application has its own owners.
+type BotOwners struct {
+	mu     sync.Mutex
+	owners map[discord.UserID]map[discord.UserID]bool
+}
+
+// NewBotOwners creates an empty owner cache
+func NewBotOwners() *BotOwners {
+	return &BotOwners{owners: make(map[discord.UserID]map[discord.UserID]bool)}
+}
+
+// IsBotOwner returns a check for the user owning the application, or being a member of the application's team.
+// Owners are fetched from the API on first use, and are retried if the request fails.
+func IsBotOwner() router.Check {
+	return NewBotOwners().Check
+}
+
+// Check checks the user owns the application, or is a member of the application's team
+func (o *BotOwners) Check(ctx *router.Context) error {
+	owners, err := o.get(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if !owners[ctx.User.ID] {
+		return ErrNotBotOwner
+	}
+
+	return nil
+}
+
+// get returns the cached owners for the context's bot, fetching them if needed.
+// The lock isn't held while fetching, so checks for other bots aren't blocked by the request.
+func (o *BotOwners) get(ctx *router.Context) (map[discord.UserID]bool, error) {
+	me, err := ctx.Session.Me()
+
+	if err != nil {
+		return nil, err
+	}
+
+	o.mu.Lock()
+	owners, ok := o.owners[me.ID]
+	o.mu.Unlock()
+
+	if ok {
+		return owners, nil
+	}
+
+	app, err := ctx.Session.CurrentApplication()
+
+	if err != nil {
+		return nil, err
+	}
+
+	owners = make(map[discord.UserID]bool)
+
+	if app.Team != nil {
+		for _, member := range app.Team.Members {
+			if member.MembershipState == discord.MembershipAccepted {
+				owners[member.User.ID] = true
+			}
+		}
+	} else if app.Owner != nil {
+		owners[app.Owner.ID] = true
+	}
+
+	o.mu.Lock()
+	o.owners[me.ID] = owners
+	o.mu.Unlock()
+
+	return owners, nil
+}
+
+// UserIn checks the user is one of the specified users
+func UserIn(ids ...discord.UserID) router.Check {
+	return func(ctx *router.Context) error {
+		for _, id := range ids {
+			if ctx.User.ID == id {
+				return nil
+			}
+		}
+
+		return ErrUserNotAllowed
+	}
+}
+
+// InGuild checks the command is used in a guild
+func InGuild(ctx *router.Context) error {
+	if ctx.Guild == nil {
+		return ErrGuildOnly
+	}
+
+	return nil
+}
+
+// InDM checks the command is used in direct messages
+func InDM(ctx *router.Context) error {
+	if ctx.Guild != nil {
+		return ErrDMOnly
+	}
+
+	return nil
+}
+
+// And passes if all checks pass, returning the first failure
+func And(checks ...router.Check) router.Check {
+	return func(ctx *router.Context) error {
+		for _, check := range checks {
+			if err := check(ctx); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Or passes if any check passes. If all checks fail, the first failure is returned.
+func Or(checks ...router.Check) router.Check {
+	return func(ctx *router.Context) error {
+		var first error
+
+		for _, check := range checks {
+			err := check(ctx)
+
+			if err == nil {
+				return nil
+			}
+
+			if first == nil {
+				first = err
+			}
+		}
+
+		return first
+	}
+}
+
+// Not passes if the check fails, and returns err if it passes. If err is nil, ErrConditionNotMet is used.
+func Not(check router.Check, err error) router.Check {
+	if err == nil {
+		err = ErrConditionNotMet
+	}
+
+	return func(ctx *router.Context) error {
+		if check(ctx) == nil {
+			return err
+		}
+
+		return nil
+	}
+}
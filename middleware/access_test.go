@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"meow.tf/astral/router"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRequireRole(t *testing.T) {
+	ctx := newTestContext()
+	ctx.Event.Member.RoleIDs = []discord.RoleID{10}
+
+	if handled, called := callRoute(t, ctx, RequireRole(10, nil)); handled != nil || !called {
+		t.Fatal("Expected the route to run, got:", handled)
+	}
+
+	if handled, called := callRoute(t, ctx, RequireAnyRole([]discord.RoleID{11, 12}, nil)); handled != ErrMissingRole || called {
+		t.Fatal("Expected missing role, got:", handled)
+	}
+
+	ctx.Guild = nil
+
+	if handled, _ := callRoute(t, ctx, RequireRole(10, nil)); handled != ErrGuildOnly {
+		t.Fatal("Expected guild only error, got:", handled)
+	}
+}
+
+func TestRequireOwner(t *testing.T) {
+	ctx := newTestContext()
+
+	if handled, _ := callRoute(t, ctx, RequireOwner(nil)); handled != ErrNotGuildOwner {
+		t.Fatal("Expected not owner error, got:", handled)
+	}
+
+	ctx.Guild.OwnerID = ctx.User.ID
+
+	if handled, called := callRoute(t, ctx, RequireOwner(nil)); handled != nil || !called {
+		t.Fatal("Expected the route to run, got:", handled)
+	}
+}
+
+// newOwnerState creates a state for a bot, whose application is owned by a team, counting application requests
+func newOwnerState(botID, ownerID string, apps *int) *state.State {
+	s := state.New("Bot test")
+	s.Client.Client.Client = httpdriver.WrapClient(http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.Header().Set("Content-Type", "application/json")
+
+			if r.URL.Path == "/api/v9/users/@me" {
+				rec.WriteString(`{"id":"` + botID + `","bot":true}`)
+				return rec.Result(), nil
+			}
+
+			*apps++
+
+			rec.WriteString(`{"id":"` + botID + `","owner":{"id":"1"},"team":{"id":"2","members":[
+				{"membership_state":2,"user":{"id":"` + ownerID + `"}},
+				{"membership_state":1,"user":{"id":"9"}}
+			]}}`)
+
+			return rec.Result(), nil
+		}),
+	})
+
+	return s
+}
+
+func TestRequireBotOwner(t *testing.T) {
+	apps := 0
+
+	s := newOwnerState("99", "8", &apps)
+
+	check := RequireBotOwner(nil)
+
+	for _, test := range []struct {
+		user  discord.UserID
+		owner bool
+	}{{8, true}, {9, false}, {1, false}} {
+		ctx := newTestContext()
+		ctx.Session = s
+		ctx.User.ID = test.user
+
+		handled, called := callRoute(t, ctx, check)
+
+		if called != test.owner || (handled == ErrNotBotOwner) == test.owner {
+			t.Fatal("Unexpected result for user", test.user, handled, called)
+		}
+	}
+
+	if apps != 1 {
+		t.Fatal("Expected owners to be fetched once, got:", apps)
+	}
+}
+
+func TestBotOwners_PerApplication(t *testing.T) {
+	apps := 0
+
+	owners := NewBotOwners()
+
+	first, second := newTestContext(), newTestContext()
+	first.Session = newOwnerState("99", "8", &apps)
+	second.Session = newOwnerState("100", "10", &apps)
+
+	if err := owners.Check(first); err != nil {
+		t.Fatal("Expected owner of the first bot, got:", err)
+	}
+
+	if err := owners.Check(second); err != ErrNotBotOwner {
+		t.Fatal("Expected the second bot to have its own owners, got:", err)
+	}
+
+	if apps != 2 {
+		t.Fatal("Expected each application to be fetched, got:", apps)
+	}
+}
+
+func TestUserLists(t *testing.T) {
+	ctx := newTestContext()
+
+	if handled, called := callRoute(t, ctx, AllowUsers([]discord.UserID{8}, nil)); handled != nil || !called {
+		t.Fatal("Expected allowed user to run, got:", handled)
+	}
+
+	if handled, _ := callRoute(t, ctx, AllowUsers([]discord.UserID{9}, nil)); handled != ErrUserNotAllowed {
+		t.Fatal("Expected user not allowed, got:", handled)
+	}
+
+	if handled, _ := callRoute(t, ctx, DenyUsers([]discord.UserID{8}, nil)); handled != ErrUserNotAllowed {
+		t.Fatal("Expected denied user, got:", handled)
+	}
+}
+
+func TestGuildOnly(t *testing.T) {
+	ctx := newTestContext()
+
+	if handled, _ := callRoute(t, ctx, DMOnly(nil)); handled != ErrDMOnly {
+		t.Fatal("Expected DM only error, got:", handled)
+	}
+
+	ctx.Guild = nil
+
+	if handled, _ := callRoute(t, ctx, GuildOnly(nil)); handled != ErrGuildOnly {
+		t.Fatal("Expected guild only error, got:", handled)
+	}
+}
+
+func TestCombinators(t *testing.T) {
+	ctx := newTestContext()
+
+	pass := func(ctx *router.Context) error { return nil }
+
+	if err := And(pass, InGuild, UserIn(8))(ctx); err != nil {
+		t.Fatal("Expected And to pass, got:", err)
+	}
+
+	if err := And(pass, InDM)(ctx); err != ErrDMOnly {
+		t.Fatal("Expected And to fail with DM only, got:", err)
+	}
+
+	if err := Or(IsGuildOwner, InDM, UserIn(8))(ctx); err != nil {
+		t.Fatal("Expected Or to pass, got:", err)
+	}
+
+	if err := Or(IsGuildOwner, InDM)(ctx); err != ErrNotGuildOwner {
+		t.Fatal("Expected Or to return the first failure, got:", err)
+	}
+
+	if err := Not(InDM, nil)(ctx); err != nil {
+		t.Fatal("Expected Not to pass, got:", err)
+	}
+
+	if err := Not(InGuild, nil)(ctx); err != ErrConditionNotMet {
+		t.Fatal("Expected Not to fail, got:", err)
+	}
+
+	if handled, _ := callRoute(t, ctx, Require(Or(IsGuildOwner, HasAnyRole(10)), nil)); handled != ErrNotGuildOwner {
+		t.Fatal("Expected combined check to fail, got:", handled)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"meow.tf/astral/router"
+	"strconv"
 )
 
 // Errors
@@ -12,12 +13,27 @@ var (
 	ErrChannelType    = errors.New("channel type does not match expected type")
 )
 
+// ChannelTypeError is returned when the channel type doesn't match, and matches ErrChannelType
+type ChannelTypeError struct {
+	Expected discord.ChannelType
+	Actual   discord.ChannelType
+}
+
+func (e *ChannelTypeError) Error() string {
+	return ErrChannelType.Error() + ": expected " + strconv.Itoa(int(e.Expected)) + ", got " + strconv.Itoa(int(e.Actual))
+}
+
+// Is matches ErrChannelType
+func (e *ChannelTypeError) Is(target error) bool {
+	return target == ErrChannelType
+}
+
 // RequireNSFW requires a message to be sent from an NSFW channel
 func RequireNSFW(catch CatchFunc) router.MiddlewareFunc {
 	return func(fn router.Handler) router.Handler {
 		return func(ctx *router.Context) {
-			if !ctx.Channel.NSFW {
-				callCatch(ctx, catch, ErrChannelNotNSFW)
+			if ctx.Channel == nil || !ctx.Channel.NSFW {
+				Catch(ctx, catch, ErrChannelNotNSFW)
 				return
 			}
 			fn(ctx)
@@ -29,8 +45,14 @@ func RequireNSFW(catch CatchFunc) router.MiddlewareFunc {
 func ChannelType(t discord.ChannelType, catch CatchFunc) router.MiddlewareFunc {
 	return func(fn router.Handler) router.Handler {
 		return func(ctx *router.Context) {
-			if ctx.Channel.Type != t {
-				callCatch(ctx, catch, ErrChannelType)
+			if ctx.Channel == nil || ctx.Channel.Type != t {
+				err := &ChannelTypeError{Expected: t}
+
+				if ctx.Channel != nil {
+					err.Actual = ctx.Channel.Type
+				}
+
+				Catch(ctx, catch, err)
 				return
 			}
 			fn(ctx)
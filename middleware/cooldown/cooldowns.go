@@ -1,8 +1,9 @@
 package cooldown
 
 import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/timedmap"
-	"golang.org/x/time/rate"
 	"meow.tf/astral/middleware"
 	"meow.tf/astral/router"
 	"strings"
@@ -19,6 +20,7 @@ const (
 
 var (
 	rateLimiters = timedmap.New()
+	sharedStore  = &MemoryStore{m: rateLimiters}
 	cl           *timedmap.Cleaner
 )
 
@@ -29,44 +31,113 @@ func init() {
 	cl.Start()
 }
 
+// CooldownError is passed to the catch function when the limit is hit, and is available from Error or middleware.Error
+type CooldownError struct {
+	// RetryAfter is the time until the command can be used again
+	RetryAfter time.Duration
+	// Key is the bucket key, which can be reset with Handler.Reset
+	Key string
+	// Limit is the number of uses allowed per time frame, including overrides
+	Limit int
+}
+
+// Error describes how long to wait, rounded up to the second
+func (e *CooldownError) Error() string {
+	return "cooldown: try again in " + (e.RetryAfter + time.Second - 1).Truncate(time.Second).String()
+}
+
+// Error returns the cooldown error from the context, if the limit was hit
+func Error(ctx *router.Context) *CooldownError {
+	var err *CooldownError
+
+	if !errors.As(middleware.Error(ctx), &err) {
+		return nil
+	}
+
+	return err
+}
+
 type Handler struct {
-	m         *timedmap.Map
+	store     Store
 	limit     int
 	timeFrame time.Duration
 	flags     int
 	catch     middleware.CatchFunc
+	exempt    []func(ctx *router.Context) bool
+	overrides []func(ctx *router.Context) (int, bool)
+	keyFunc   KeyFunc
+	onError   StoreErrorFunc
 }
 
-// Finds or creates a new limiter for the specified ctx
-func (h *Handler) limiterOrNew(ctx *router.Context) *rate.Limiter {
-	key := limiterKey(ctx, h.flags)
+// KeyFunc builds a bucket key for a context
+type KeyFunc func(ctx *router.Context) string
 
-	var limiter *rate.Limiter
+// Option configures a cooldown handler
+type Option func(h *Handler)
 
-	if v, exists := h.m.Get(key); exists {
-		limiter = v.Value.(*rate.Limiter)
+// WithStore sets the store used to keep cooldown buckets, instead of an in-memory store
+func WithStore(store Store) Option {
+	return func(h *Handler) {
+		h.store = store
+	}
+}
 
-		if v.ExpiryTime().Before(time.Now().Add(h.timeFrame)) {
-			h.m.Extend(key, h.timeFrame)
-		}
-	} else {
-		limiter = rate.NewLimiter(rate.Limit(float64(h.limit)/h.timeFrame.Seconds()), h.limit)
+// WithKey sets a function to build bucket keys, instead of building them from the flags.
+// For example, a key could group several commands into a single bucket.
+func WithKey(fn KeyFunc) Option {
+	return func(h *Handler) {
+		h.keyFunc = fn
+	}
+}
 
-		h.m.Set(key, limiter, h.timeFrame*2)
+// StoreErrorFunc is called when the store fails, such as when a shared store is unreachable.
+// It returns whether the command is allowed to run anyway.
+type StoreErrorFunc func(ctx *router.Context, err error) bool
+
+// OnStoreError sets a function called when the store fails, instead of passing the error to the route's ErrorHandler.
+func OnStoreError(fn StoreErrorFunc) Option {
+	return func(h *Handler) {
+		h.onError = fn
 	}
+}
 
-	return limiter
+// storeError passes a store error to the route's ErrorHandler, and allows the command to run
+func storeError(ctx *router.Context, err error) bool {
+	if r := ctx.Route(); r != nil {
+		if errorHandler := r.ErrorHandler(); errorHandler != nil {
+			errorHandler(ctx, err)
+		}
+	}
+
+	return true
 }
 
-// Middleware function for the handler
+// Middleware function for the handler.
+// If the store fails, the error is passed to the OnStoreError function, which allows the command to run by default.
 func (h *Handler) Middleware(fn router.Handler) router.Handler {
 	return func(ctx *router.Context) {
-		limiter := h.limiterOrNew(ctx)
+		for _, exempt := range h.exempt {
+			if exempt(ctx) {
+				fn(ctx)
+				return
+			}
+		}
 
-		if !limiter.Allow() {
-			if h.catch != nil {
-				h.catch(ctx)
+		key := h.Key(ctx)
+		limit := h.Limit(ctx)
+
+		allowed, retryAfter, err := h.store.Allow(key, limit, h.timeFrame)
+
+		if err != nil {
+			if h.onError(ctx, err) {
+				fn(ctx)
 			}
+
+			return
+		}
+
+		if !allowed {
+			middleware.Catch(ctx, h.catch, &CooldownError{RetryAfter: retryAfter, Key: key, Limit: limit})
 			return
 		}
 
@@ -74,52 +145,127 @@ func (h *Handler) Middleware(fn router.Handler) router.Handler {
 	}
 }
 
-// Create a new limiter which does nothing when the limit is hit
-func New(limit int, timeFrame time.Duration, flags int) router.MiddlewareFunc {
-	return NewWithCatch(limit, timeFrame, flags, nil)
+// Key returns the bucket key for a context
+func (h *Handler) Key(ctx *router.Context) string {
+	if h.keyFunc != nil {
+		return h.keyFunc(ctx)
+	}
+
+	return limiterKey(ctx, h.flags)
 }
 
-// Create a new limiter which calls "catch" if set when the limit is hit.
-func NewWithCatch(limit int, timeFrame time.Duration, flags int, catch middleware.CatchFunc) router.MiddlewareFunc {
-	m := timedmap.New()
+// Limit returns the limit for a context, which is the first matching override or the handler's limit
+func (h *Handler) Limit(ctx *router.Context) int {
+	for _, override := range h.overrides {
+		if limit, ok := override(ctx); ok {
+			return limit
+		}
+	}
+
+	return h.limit
+}
+
+// Reset clears a bucket, such as from an admin command. Keys are returned by Key and BucketKey.
+func (h *Handler) Reset(key string) error {
+	return h.store.Reset(key, h.timeFrame)
+}
 
-	cl.AddCleanable(m)
+// NewHandler creates a new limiter which calls "catch" if set when the limit is hit.
+// Unlike NewWithCatch, the handler is returned, so buckets can be reset. Use Handler.Middleware as the middleware.
+func NewHandler(limit int, timeFrame time.Duration, flags int, catch middleware.CatchFunc, opts ...Option) *Handler {
+	return newHandler(NewMemoryStore(), limit, timeFrame, flags, catch, opts)
+}
 
-	h := &Handler{m, limit, timeFrame, flags, catch}
+// Create a new limiter which passes a CooldownError to the route's ErrorHandler when the limit is hit
+func New(limit int, timeFrame time.Duration, flags int, opts ...Option) router.MiddlewareFunc {
+	return NewWithCatch(limit, timeFrame, flags, nil, opts...)
+}
 
-	return h.Middleware
+// Create a new limiter which calls "catch" when the limit is hit.
+// If catch is nil, the CooldownError is passed to the route's ErrorHandler.
+func NewWithCatch(limit int, timeFrame time.Duration, flags int, catch middleware.CatchFunc, opts ...Option) router.MiddlewareFunc {
+	return NewHandler(limit, timeFrame, flags, catch, opts...).Middleware
 }
 
 // Create a new limiter shared across the application
-func NewShared(limit int, timeFrame time.Duration, flags int) router.MiddlewareFunc {
-	return NewSharedWithCatch(limit, timeFrame, flags, nil)
+func NewShared(limit int, timeFrame time.Duration, flags int, opts ...Option) router.MiddlewareFunc {
+	return NewSharedWithCatch(limit, timeFrame, flags, nil, opts...)
 }
 
 // Create a new limiter shared across the application which calls "catch" if set when the limit is hit.
-func NewSharedWithCatch(limit int, timeFrame time.Duration, flags int, catch middleware.CatchFunc) router.MiddlewareFunc {
-	h := &Handler{rateLimiters, limit, timeFrame, flags, catch}
+func NewSharedWithCatch(limit int, timeFrame time.Duration, flags int, catch middleware.CatchFunc, opts ...Option) router.MiddlewareFunc {
+	return newHandler(sharedStore, limit, timeFrame, flags, catch, opts).Middleware
+}
+
+// newHandler creates a handler using the default store, unless one is set by the options
+func newHandler(store Store, limit int, timeFrame time.Duration, flags int, catch middleware.CatchFunc, opts []Option) *Handler {
+	h := &Handler{store: store, limit: limit, timeFrame: timeFrame, flags: flags, catch: catch, onError: storeError}
 
-	return h.Middleware
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
 }
 
-// Construct the rate limiter key from the context given the set of flags
+// Bucket identifies a cooldown bucket, to build keys for other users or channels, such as to reset them.
+// Only the fields selected by the handler's flags are used.
+type Bucket struct {
+	// Command is the route's full path, such as "admin roles add"
+	Command   string
+	UserID    discord.UserID
+	ChannelID discord.ChannelID
+	// GuildID is unset in direct messages, which use the channel as the server instead
+	GuildID discord.GuildID
+}
+
+// BucketKey returns the key of a bucket. Custom key functions aren't used.
+func (h *Handler) BucketKey(b Bucket) string {
+	return b.key(h.flags)
+}
+
+// Construct the rate limiter key from the context given the set of flags.
+// Commands are identified by the route's path, which is the same for messages and interactions.
 func limiterKey(ctx *router.Context, flags int) string {
+	b := Bucket{Command: ctx.Command, UserID: ctx.User.ID}
+
+	if r := ctx.Route(); r != nil {
+		b.Command = strings.Join(r.Path(), " ")
+	}
+
+	if ctx.Channel != nil && (flags&Channel != 0 || flags&Server != 0 && ctx.Guild == nil) {
+		b.ChannelID = ctx.Channel.ID
+	}
+
+	if flags&Server != 0 && ctx.Guild != nil {
+		b.GuildID = ctx.Guild.ID
+	}
+
+	return b.key(flags)
+}
+
+// key joins the parts of the bucket selected by the flags
+func (b Bucket) key(flags int) string {
 	k := make([]string, 0)
 
 	if flags&Command != 0 {
-		k = append(k, "command", ctx.Command)
+		k = append(k, "command", b.Command)
 	}
 
 	if flags&User != 0 {
-		k = append(k, "user", ctx.User.ID.String())
+		k = append(k, "user", b.UserID.String())
 	}
 
 	if flags&Channel != 0 {
-		k = append(k, "channel", ctx.Channel.ID.String())
+		k = append(k, "channel", b.ChannelID.String())
 	}
 
 	if flags&Server != 0 {
-		k = append(k, "guild", ctx.Guild.ID.String())
+		if b.GuildID.IsValid() {
+			k = append(k, "guild", b.GuildID.String())
+		} else {
+			k = append(k, "dm", b.ChannelID.String())
+		}
 	}
 
 	if flags&Global != 0 {
@@ -0,0 +1,251 @@
+package cooldown
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"meow.tf/astral/router"
+	"testing"
+	"time"
+)
+
+func newTestContext(userID discord.UserID, guildID discord.GuildID) *router.Context {
+	return &router.Context{
+		VariableBag: router.NewVariableBag(),
+		Guild:       &discord.Guild{ID: guildID},
+		Channel:     &discord.Channel{ID: 6, GuildID: guildID},
+		User:        discord.User{ID: userID},
+		Command:     "test",
+	}
+}
+
+// callCount calls the middleware n times, returning how many calls ran the handler
+func callCount(h *Handler, ctx *router.Context, n int) int {
+	called := 0
+
+	fn := h.Middleware(func(ctx *router.Context) {
+		called++
+	})
+
+	for i := 0; i < n; i++ {
+		fn(ctx)
+	}
+
+	return called
+}
+
+func TestHandler_CooldownError(t *testing.T) {
+	var caught *CooldownError
+
+	h := NewHandler(1, time.Hour, Command|User, func(ctx *router.Context) {
+		caught = Error(ctx)
+	})
+
+	ctx := newTestContext(8, 5)
+
+	if called := callCount(h, ctx, 2); called != 1 {
+		t.Fatal("Expected 1 call, got:", called)
+	}
+
+	if caught == nil || caught.Key != "command_test_user_8" || caught.Limit != 1 || caught.RetryAfter <= 0 {
+		t.Fatal("Expected cooldown error, got:", caught)
+	}
+
+	if err := (&CooldownError{RetryAfter: 1500 * time.Millisecond}); err.Error() != "cooldown: try again in 2s" {
+		t.Fatal("Unexpected error message:", err)
+	}
+}
+
+func TestHandler_Overrides(t *testing.T) {
+	h := NewHandler(1, time.Hour, User, nil, ExemptUsers(1), OverrideGuilds(3, 10))
+
+	if called := callCount(h, newTestContext(1, 5), 5); called != 5 {
+		t.Fatal("Expected exempt user to be allowed, got:", called)
+	}
+
+	if called := callCount(h, newTestContext(8, 10), 5); called != 3 {
+		t.Fatal("Expected guild override of 3, got:", called)
+	}
+
+	if called := callCount(h, newTestContext(9, 5), 5); called != 1 {
+		t.Fatal("Expected default limit of 1, got:", called)
+	}
+
+	h = NewHandler(1, time.Hour, User, nil, ExemptRoles(20))
+
+	ctx := newTestContext(8, 5)
+	ctx.Event = &gateway.MessageCreateEvent{Member: &discord.Member{RoleIDs: []discord.RoleID{20}}}
+
+	if called := callCount(h, ctx, 3); called != 3 {
+		t.Fatal("Expected exempt role to be allowed, got:", called)
+	}
+}
+
+func TestHandler_Reset(t *testing.T) {
+	h := NewHandler(1, time.Hour, Command|User, nil)
+
+	ctx := newTestContext(8, 5)
+
+	if called := callCount(h, ctx, 2); called != 1 {
+		t.Fatal("Expected 1 call, got:", called)
+	}
+
+	if err := h.Reset(h.BucketKey(Bucket{Command: "test", UserID: 8})); err != nil {
+		t.Fatal(err)
+	}
+
+	if called := callCount(h, ctx, 1); called != 1 {
+		t.Fatal("Expected the bucket to be reset")
+	}
+}
+
+// newTestState creates a state with a cached guild, guild channel and DM channel, so contexts can be created without requests
+func newTestState(t *testing.T) *state.State {
+	s := state.NewWithIntents("Bot test", gateway.IntentGuilds, gateway.IntentDirectMessages)
+
+	if err := s.Cabinet.GuildSet(&discord.Guild{ID: 5}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Cabinet.ChannelSet(&discord.Channel{ID: 6, GuildID: 5, Type: discord.GuildText}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Cabinet.ChannelSet(&discord.Channel{ID: 7, Type: discord.DirectMessage, DMRecipients: []discord.User{{ID: 8}}}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestLimiterKey_Messages(t *testing.T) {
+	s := newTestState(t)
+
+	r := router.New()
+	rt := r.On("admin", nil).On("ping", nil)
+
+	event := &gateway.MessageCreateEvent{
+		Message: discord.Message{ChannelID: 6, GuildID: 5, Author: discord.User{ID: 8}},
+	}
+
+	ctx, err := router.ContextFrom(s, event, rt, nil, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key := limiterKey(ctx, Command|User|Server); key != "command_admin ping_user_8_guild_5" {
+		t.Fatal("Unexpected key:", key)
+	}
+
+	event.ChannelID = 7
+	event.GuildID = 0
+
+	ctx, err = router.ContextFrom(s, event, rt, nil, "")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key := limiterKey(ctx, Command|Server); key != "command_admin ping_dm_7" {
+		t.Fatal("Unexpected DM key:", key)
+	}
+}
+
+func TestLimiterKey_Interactions(t *testing.T) {
+	s := newTestState(t)
+
+	noop := func(ctx *router.Context) {}
+
+	r := router.New()
+	r.On("ping", noop)
+	r.On("pong", noop)
+
+	key := func(name string) string {
+		event := &gateway.InteractionCreateEvent{
+			InteractionEvent: discord.InteractionEvent{
+				ChannelID: 6,
+				GuildID:   5,
+				Member:    &discord.Member{User: discord.User{ID: 8}},
+				Data:      &discord.CommandInteraction{Name: name},
+			},
+		}
+
+		ctx, err := router.ContextFromInteraction(s, event, r.Find(name))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return limiterKey(ctx, Command|User)
+	}
+
+	if ping, pong := key("ping"), key("pong"); ping != "command_ping_user_8" || pong != "command_pong_user_8" {
+		t.Fatal("Expected separate buckets per command, got:", ping, pong)
+	}
+}
+
+func TestHandler_KeyFunc(t *testing.T) {
+	h := NewHandler(1, time.Hour, Command, nil, WithKey(func(ctx *router.Context) string {
+		return "games_" + ctx.User.ID.String()
+	}))
+
+	if key := h.Key(newTestContext(8, 5)); key != "games_8" {
+		t.Fatal("Expected custom key, got:", key)
+	}
+
+	if called := callCount(h, newTestContext(8, 5), 2); called != 1 {
+		t.Fatal("Expected the custom bucket to be limited, got:", called)
+	}
+}
+
+// failingStore is a store which is always unreachable
+type failingStore struct{}
+
+var errStoreFailed = errors.New("store failed")
+
+func (failingStore) Allow(key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	return false, 0, errStoreFailed
+}
+
+func (failingStore) Reset(key string, window time.Duration) error {
+	return errStoreFailed
+}
+
+func TestHandler_StoreError(t *testing.T) {
+	var handled error
+
+	r := router.New()
+
+	r.SetErrorHandler(func(ctx *router.Context, err error) {
+		handled = err
+	})
+
+	called := false
+
+	r.Use(NewWithCatch(1, time.Hour, User, nil, WithStore(failingStore{})))
+
+	rt := r.On("test", func(ctx *router.Context) {
+		called = true
+	})
+
+	if err := rt.Call(newTestContext(8, 5)); err != nil {
+		t.Fatal(err)
+	}
+
+	if handled != errStoreFailed || !called {
+		t.Fatal("Expected the error handler to be called and the command to run, got:", handled, called)
+	}
+
+	var stored error
+
+	h := NewHandler(1, time.Hour, User, nil, WithStore(failingStore{}), OnStoreError(func(ctx *router.Context, err error) bool {
+		stored = err
+		return false
+	}))
+
+	if called := callCount(h, newTestContext(8, 5), 1); called != 0 || stored != errStoreFailed {
+		t.Fatal("Expected the store error callback to deny the command, got:", called, stored)
+	}
+}
@@ -0,0 +1,110 @@
+package cooldown
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"meow.tf/astral/router"
+)
+
+// Exempt skips the cooldown for contexts where fn returns true
+func Exempt(fn func(ctx *router.Context) bool) Option {
+	return func(h *Handler) {
+		h.exempt = append(h.exempt, fn)
+	}
+}
+
+// ExemptUsers skips the cooldown for the specified users
+func ExemptUsers(ids ...discord.UserID) Option {
+	return Exempt(matchUsers(ids))
+}
+
+// ExemptRoles skips the cooldown for members with any of the specified roles
+func ExemptRoles(ids ...discord.RoleID) Option {
+	return Exempt(matchRoles(ids))
+}
+
+// ExemptGuilds skips the cooldown in the specified guilds
+func ExemptGuilds(ids ...discord.GuildID) Option {
+	return Exempt(matchGuilds(ids))
+}
+
+// Override sets the limit for contexts where fn returns true, such as premium guilds.
+// The first matching override is used.
+func Override(fn func(ctx *router.Context) (int, bool)) Option {
+	return func(h *Handler) {
+		h.overrides = append(h.overrides, fn)
+	}
+}
+
+// OverrideUsers sets the limit for the specified users
+func OverrideUsers(limit int, ids ...discord.UserID) Option {
+	return Override(overrideMatch(limit, matchUsers(ids)))
+}
+
+// OverrideRoles sets the limit for members with any of the specified roles
+func OverrideRoles(limit int, ids ...discord.RoleID) Option {
+	return Override(overrideMatch(limit, matchRoles(ids)))
+}
+
+// OverrideGuilds sets the limit in the specified guilds
+func OverrideGuilds(limit int, ids ...discord.GuildID) Option {
+	return Override(overrideMatch(limit, matchGuilds(ids)))
+}
+
+func overrideMatch(limit int, match func(ctx *router.Context) bool) func(ctx *router.Context) (int, bool) {
+	return func(ctx *router.Context) (int, bool) {
+		return limit, match(ctx)
+	}
+}
+
+func matchUsers(ids []discord.UserID) func(ctx *router.Context) bool {
+	return func(ctx *router.Context) bool {
+		for _, id := range ids {
+			if ctx.User.ID == id {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func matchGuilds(ids []discord.GuildID) func(ctx *router.Context) bool {
+	return func(ctx *router.Context) bool {
+		if ctx.Guild == nil {
+			return false
+		}
+
+		for _, id := range ids {
+			if ctx.Guild.ID == id {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// matchRoles matches members with any of the roles. Members which can't be found don't match.
+func matchRoles(ids []discord.RoleID) func(ctx *router.Context) bool {
+	return func(ctx *router.Context) bool {
+		if ctx.Guild == nil {
+			return false
+		}
+
+		member, err := ctx.Member()
+
+		if err != nil {
+			return false
+		}
+
+		for _, roleID := range member.RoleIDs {
+			for _, id := range ids {
+				if roleID == id {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+}
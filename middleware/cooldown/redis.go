@@ -0,0 +1,340 @@
+package cooldown
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRedisNil = errors.New("redis: nil reply")
+)
+
+// RedisStore is a sliding window store, which keeps counters on a Redis compatible server.
+// Uses are counted per fixed window, and the previous window's count is weighted by how much of it
+// overlaps the sliding window, so limits are shared between every process using the same server.
+type RedisStore struct {
+	// Addr is the server address, such as localhost:6379
+	Addr string
+	// Password is sent with AUTH if set
+	Password string
+	// DB is selected with SELECT if set
+	DB int
+	// Prefix is prepended to every key, "astral:cooldown:" by default
+	Prefix string
+	// Timeout is the dial and command timeout, 5 seconds by default
+	Timeout time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisStore creates a new store using the Redis compatible server at addr
+func NewRedisStore(addr string) *RedisStore {
+	return &RedisStore{
+		Addr:    addr,
+		Prefix:  "astral:cooldown:",
+		Timeout: 5 * time.Second,
+	}
+}
+
+// allowScript counts a use in the current window, and uncounts it if the weighted count exceeds the limit.
+// Running it as a script makes the check atomic, so concurrent uses can't exceed the limit.
+// KEYS are the current and previous window's counters, ARGV is the expiry in milliseconds, the elapsed
+// fraction of the current window and the limit. It replies with whether the use is allowed and both counts.
+const allowScript = `local current = redis.call('INCR', KEYS[1])
+if current == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+local previous = tonumber(redis.call('GET', KEYS[2]) or 0)
+if previous * (1 - tonumber(ARGV[2])) + current <= tonumber(ARGV[3]) then
+	return {1, current, previous}
+end
+redis.call('DECR', KEYS[1])
+return {0, current - 1, previous}`
+
+// Allow counts a use in the current window, rejecting it if the weighted count exceeds the limit
+func (s *RedisStore) Allow(key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	// Overrides can disable a command with a limit of 0, which is retried after a window without counting the use
+	if limit <= 0 {
+		return false, window, nil
+	}
+
+	now := time.Now()
+	index := now.UnixNano() / int64(window)
+	elapsed := float64(now.UnixNano()-index*int64(window)) / float64(window)
+
+	currentKey := s.Prefix + key + ":" + strconv.FormatInt(index, 10)
+	previousKey := s.Prefix + key + ":" + strconv.FormatInt(index-1, 10)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Counters are kept while they're the current or previous window
+	reply, err := s.integers("EVAL", allowScript, "2", currentKey, previousKey,
+		strconv.FormatInt(int64(window*2/time.Millisecond), 10),
+		strconv.FormatFloat(elapsed, 'f', -1, 64),
+		strconv.Itoa(limit))
+
+	if err != nil {
+		return false, 0, err
+	}
+
+	if len(reply) != 3 {
+		return false, 0, fmt.Errorf("redis: unexpected reply %v", reply)
+	}
+
+	if reply[0] == 1 {
+		return true, 0, nil
+	}
+
+	return false, slidingRetryAfter(reply[2], reply[1], limit, elapsed, window), nil
+}
+
+// Reset deletes the bucket's counters for the current and previous windows
+func (s *RedisStore) Reset(key string, window time.Duration) error {
+	index := time.Now().UnixNano() / int64(window)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.integer("DEL", s.Prefix+key+":"+strconv.FormatInt(index, 10), s.Prefix+key+":"+strconv.FormatInt(index-1, 10))
+	return err
+}
+
+// slidingRetryAfter calculates when the weighted count drops enough to allow another use
+func slidingRetryAfter(previous, current int64, limit int, elapsed float64, window time.Duration) time.Duration {
+	if limit <= 0 {
+		return window
+	}
+
+	free := float64(limit - 1)
+
+	if float64(current) <= free {
+		// Wait for the previous window's weight to drop within this window
+		return time.Duration((1 - (free-float64(current))/float64(previous) - elapsed) * float64(window))
+	}
+
+	// Wait for the next window, where this window becomes the previous one
+	return time.Duration((1 - elapsed + 1 - free/float64(current)) * float64(window))
+}
+
+// integer runs a command which replies with an integer, or a string containing one
+func (s *RedisStore) integer(args ...string) (int64, error) {
+	reply, err := s.do(args...)
+
+	if err != nil {
+		return 0, err
+	}
+
+	switch v := reply.(type) {
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	}
+
+	return 0, fmt.Errorf("redis: unexpected reply %v", reply)
+}
+
+// integers runs a command which replies with an array of integers
+func (s *RedisStore) integers(args ...string) ([]int64, error) {
+	reply, err := s.do(args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values, ok := reply.([]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected reply %v", reply)
+	}
+
+	ints := make([]int64, len(values))
+
+	for i, v := range values {
+		if ints[i], ok = v.(int64); !ok {
+			return nil, fmt.Errorf("redis: unexpected reply %v", reply)
+		}
+	}
+
+	return ints, nil
+}
+
+// do sends a command and reads its reply, reconnecting if there is no connection.
+// The connection is closed on errors, as the protocol state is unknown.
+func (s *RedisStore) do(args ...string) (interface{}, error) {
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := s.roundTrip(args...)
+
+	if err != nil && err != ErrRedisNil {
+		if _, ok := err.(redisError); !ok {
+			s.close()
+		}
+	}
+
+	return reply, err
+}
+
+// connect dials the server, authenticating and selecting the database if set
+func (s *RedisStore) connect() error {
+	conn, err := net.DialTimeout("tcp", s.Addr, s.Timeout)
+
+	if err != nil {
+		return err
+	}
+
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+
+	if s.Password != "" {
+		if _, err = s.roundTrip("AUTH", s.Password); err != nil {
+			s.close()
+			return err
+		}
+	}
+
+	if s.DB != 0 {
+		if _, err = s.roundTrip("SELECT", strconv.Itoa(s.DB)); err != nil {
+			s.close()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the connection to the server. It is reopened by the next command.
+func (s *RedisStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.close()
+}
+
+func (s *RedisStore) close() error {
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+
+	return err
+}
+
+// roundTrip writes a command as an array of bulk strings, and reads the reply
+func (s *RedisStore) roundTrip(args ...string) (interface{}, error) {
+	if s.Timeout > 0 {
+		s.conn.SetDeadline(time.Now().Add(s.Timeout))
+	}
+
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+
+	if _, err := s.conn.Write(buf); err != nil {
+		return nil, err
+	}
+
+	return readReply(s.reader)
+}
+
+// redisError is an error reply from the server
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// readReply reads a single reply. Arrays are returned as []interface{}.
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, errors.New("redis: invalid reply")
+	}
+
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		if n < 0 {
+			return nil, ErrRedisNil
+		}
+
+		b := make([]byte, n+2)
+
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+
+		return string(b[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+
+		if err != nil {
+			return nil, err
+		}
+
+		if n < 0 {
+			return nil, ErrRedisNil
+		}
+
+		values := make([]interface{}, n)
+
+		for i := range values {
+			v, err := readReply(r)
+
+			// Nil and error elements are kept, so the rest of the array is still read
+			if e, ok := err.(redisError); ok {
+				v = e
+			} else if err != nil && err != ErrRedisNil {
+				return nil, err
+			}
+
+			values[i] = v
+		}
+
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("redis: unsupported reply type %q", line[0])
+}
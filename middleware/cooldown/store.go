@@ -0,0 +1,93 @@
+package cooldown
+
+import (
+	"github.com/diamondburned/timedmap"
+	"golang.org/x/time/rate"
+	"sync"
+	"time"
+)
+
+// Store keeps the state of cooldown buckets, which allows limits to be kept in memory,
+// or shared across shards and processes.
+type Store interface {
+	// Allow takes a use from the bucket with the given key, which allows limit uses per window.
+	// If the bucket is empty, it returns false and the time until the next use is allowed.
+	Allow(key string, limit int, window time.Duration) (bool, time.Duration, error)
+
+	// Reset clears the bucket with the given key, allowing it to be used again immediately.
+	Reset(key string, window time.Duration) error
+}
+
+// MemoryStore is a token bucket store, which keeps a rate.Limiter per bucket in memory.
+// Buckets are removed after being unused for two windows.
+type MemoryStore struct {
+	mu sync.Mutex
+	m  *timedmap.Map
+}
+
+// limiterConfig identifies a limiter within a bucket, as handlers sharing a key can have different limits
+type limiterConfig struct {
+	limit  int
+	window time.Duration
+}
+
+// NewMemoryStore creates a new in-memory store
+func NewMemoryStore() *MemoryStore {
+	m := timedmap.New()
+
+	cl.AddCleanable(m)
+
+	return &MemoryStore{m: m}
+}
+
+// Allow takes a token from the bucket's limiter, which refills at limit tokens per window
+func (s *MemoryStore) Allow(key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	limiter := s.limiterOrNew(key, limit, window)
+
+	r := limiter.Reserve()
+
+	if delay := r.Delay(); delay > 0 {
+		r.Cancel()
+		return false, delay, nil
+	}
+
+	return true, 0, nil
+}
+
+// Reset removes the bucket's limiters
+func (s *MemoryStore) Reset(key string, window time.Duration) error {
+	s.m.Remove(key)
+	return nil
+}
+
+// Finds or creates a new limiter for the specified key.
+// Each limit has its own limiter, so handlers sharing a key with different limits, such as from overrides, don't reset each other.
+func (s *MemoryStore) limiterOrNew(key string, limit int, window time.Duration) *rate.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var limiters map[limiterConfig]*rate.Limiter
+
+	if v, exists := s.m.Get(key); exists {
+		limiters = v.Value.(map[limiterConfig]*rate.Limiter)
+
+		if v.ExpiryTime().Before(time.Now().Add(window)) {
+			s.m.Extend(key, window)
+		}
+	} else {
+		limiters = make(map[limiterConfig]*rate.Limiter)
+
+		s.m.Set(key, limiters, window*2)
+	}
+
+	config := limiterConfig{limit, window}
+
+	limiter, ok := limiters[config]
+
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(float64(limit)/window.Seconds()), limit)
+		limiters[config] = limiter
+	}
+
+	return limiter
+}
@@ -0,0 +1,306 @@
+package cooldown
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testRedis is a local stand-in for a Redis server, supporting the commands used by RedisStore
+type testRedis struct {
+	mu       sync.Mutex
+	values   map[string]int64
+	commands []string
+	listener net.Listener
+}
+
+func newTestRedis(t *testing.T) *testRedis {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := &testRedis{values: make(map[string]int64), listener: l}
+
+	t.Cleanup(func() {
+		l.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+
+			if err != nil {
+				return
+			}
+
+			go r.serve(conn)
+		}
+	}()
+
+	return r
+}
+
+func (r *testRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	for {
+		args, err := readCommand(reader)
+
+		if err != nil {
+			return
+		}
+
+		conn.Write([]byte(r.handle(args)))
+	}
+}
+
+func (r *testRedis) handle(args []string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.commands = append(r.commands, strings.Join(args, " "))
+
+	switch args[0] {
+	case "AUTH", "SELECT":
+		return "+OK\r\n"
+	case "EVAL":
+		if args[1] != allowScript || args[2] != "2" {
+			return "-NOSCRIPT unknown script\r\n"
+		}
+
+		// Runs allowScript atomically, as the server would
+		current, previous := args[3], args[4]
+		elapsed, _ := strconv.ParseFloat(args[6], 64)
+		limit, _ := strconv.ParseFloat(args[7], 64)
+
+		r.values[current]++
+
+		allowed := "1"
+
+		if float64(r.values[previous])*(1-elapsed)+float64(r.values[current]) > limit {
+			allowed = "0"
+			r.values[current]--
+		}
+
+		return "*3\r\n:" + allowed + "\r\n" +
+			":" + strconv.FormatInt(r.values[current], 10) + "\r\n" +
+			":" + strconv.FormatInt(r.values[previous], 10) + "\r\n"
+	case "DEL":
+		deleted := 0
+
+		for _, key := range args[1:] {
+			if _, ok := r.values[key]; ok {
+				delete(r.values, key)
+				deleted++
+			}
+		}
+
+		return ":" + strconv.Itoa(deleted) + "\r\n"
+	}
+
+	return "-ERR unknown command\r\n"
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+
+	if err != nil {
+		return nil, err
+	}
+
+	n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, n)
+
+	for i := range args {
+		line, err := r.ReadString('\n')
+
+		if err != nil {
+			return nil, err
+		}
+
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		b := make([]byte, size+2)
+
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+
+		args[i] = string(b[:size])
+	}
+
+	return args, nil
+}
+
+func testStore(t *testing.T, store Store) {
+	for i := 0; i < 2; i++ {
+		if allowed, _, err := store.Allow("test", 2, time.Hour); err != nil || !allowed {
+			t.Fatal("Expected use", i+1, "to be allowed, got:", allowed, err)
+		}
+	}
+
+	allowed, retryAfter, err := store.Allow("test", 2, time.Hour)
+
+	if err != nil || allowed {
+		t.Fatal("Expected the limit to be hit, got:", allowed, err)
+	}
+
+	if retryAfter <= 0 || retryAfter > 2*time.Hour {
+		t.Fatal("Expected a retry after within two windows, got:", retryAfter)
+	}
+
+	if allowed, _, _ := store.Allow("other", 2, time.Hour); !allowed {
+		t.Fatal("Expected other buckets to be separate")
+	}
+
+	if err := store.Reset("other", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if allowed, _, err := store.Allow("other", 2, time.Hour); err != nil || !allowed {
+			t.Fatal("Expected reset bucket to be allowed, got:", allowed, err)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestMemoryStore_Concurrent(t *testing.T) {
+	store := NewMemoryStore()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	allowed := 0
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if ok, _, _ := store.Allow("test", 1, time.Hour); ok {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if allowed != 1 {
+		t.Fatal("Expected 1 concurrent use to be allowed, got:", allowed)
+	}
+}
+
+func TestMemoryStore_SharedKeyLimits(t *testing.T) {
+	store := NewMemoryStore()
+
+	if ok, _, _ := store.Allow("shared", 1, time.Hour); !ok {
+		t.Fatal("Expected the first use to be allowed")
+	}
+
+	for i := 0; i < 5; i++ {
+		if ok, _, _ := store.Allow("shared", 5, time.Hour); !ok {
+			t.Fatal("Expected use", i+1, "of the higher limit to be allowed")
+		}
+	}
+
+	if ok, _, _ := store.Allow("shared", 1, time.Hour); ok {
+		t.Fatal("Expected the lower limit not to be reset by the higher limit")
+	}
+}
+
+func TestRedisStore(t *testing.T) {
+	server := newTestRedis(t)
+
+	store := NewRedisStore(server.listener.Addr().String())
+	store.Password = "secret"
+
+	testStore(t, store)
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if server.commands[0] != "AUTH secret" || !strings.HasPrefix(server.commands[1], "EVAL "+allowScript+" 2 astral:cooldown:test:") {
+		t.Fatal("Unexpected commands:", server.commands)
+	}
+
+	for key, v := range server.values {
+		if strings.HasPrefix(key, "astral:cooldown:test:") && v != 2 {
+			t.Fatal("Expected rejected uses not to be counted, got:", v)
+		}
+	}
+}
+
+func TestRedisStore_ZeroLimit(t *testing.T) {
+	server := newTestRedis(t)
+
+	store := NewRedisStore(server.listener.Addr().String())
+
+	allowed, retryAfter, err := store.Allow("test", 0, time.Hour)
+
+	if err != nil || allowed || retryAfter != time.Hour {
+		t.Fatal("Expected a limit of 0 to be denied for a window, got:", allowed, retryAfter, err)
+	}
+
+	if d := slidingRetryAfter(3, 0, 0, 0.5, time.Minute); d != time.Minute {
+		t.Fatal("Expected 1m, got:", d)
+	}
+}
+
+func TestRedisStore_Reconnect(t *testing.T) {
+	server := newTestRedis(t)
+
+	store := NewRedisStore(server.listener.Addr().String())
+
+	if allowed, _, err := store.Allow("test", 2, time.Hour); err != nil || !allowed {
+		t.Fatal("Expected use to be allowed, got:", allowed, err)
+	}
+
+	store.Close()
+
+	if allowed, _, err := store.Allow("test", 2, time.Hour); err != nil || !allowed {
+		t.Fatal("Expected store to reconnect, got:", allowed, err)
+	}
+}
+
+func TestReadReply_Array(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*3\r\n:1\r\n$-1\r\n-ERR failed\r\n"))
+
+	reply, err := readReply(r)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, ok := reply.([]interface{})
+
+	if !ok || len(values) != 3 || values[0] != int64(1) || values[1] != nil || values[2] != redisError("ERR failed") {
+		t.Fatal("Unexpected reply:", reply)
+	}
+}
+
+func TestSlidingRetryAfter(t *testing.T) {
+	// Half way through the window, 4 previous uses weigh 2, so one more needs the weight to drop to 1
+	if d := slidingRetryAfter(4, 1, 3, 0.5, time.Minute); d != 15*time.Second {
+		t.Fatal("Expected 15s, got:", d)
+	}
+
+	// The current window is full, so the next window is needed
+	if d := slidingRetryAfter(0, 2, 2, 0.5, time.Minute); d != time.Minute {
+		t.Fatal("Expected 1m, got:", d)
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"meow.tf/astral/router"
 )
 
@@ -9,8 +10,14 @@ const (
 	ctxError  = ctxPrefix + "err"
 )
 
+// Errors
+var (
+	ErrGuildOnly          = router.ErrGuildOnly
+	ErrMissingPermissions = router.ErrMissingPermissions
+)
+
 // CatchFunc function called if one of the middleware experiences an error
-// Can be left as nil
+// Can be left as nil, in which case the error is passed to the route's ErrorHandler
 type CatchFunc func(ctx *router.Context)
 
 // CatchReply returns a function that prints the message you pass it
@@ -20,26 +27,58 @@ func CatchReply(message string) func(ctx *router.Context) {
 	}
 }
 
-// callCatch calls a catch function with an error
-func callCatch(ctx *router.Context, fn CatchFunc, err error) {
-	if fn == nil {
+// Error returns the error which caused the catch function to be called.
+// Errors can be compared with errors.Is, such as errors.Is(err, ErrMissingPermissions),
+// and the missing permissions are available with errors.As and *router.MissingPermissionsError.
+func Error(ctx *router.Context) error {
+	err, _ := ctx.Get(ctxError).(error)
+	return err
+}
+
+// Catch stores the error on the context for Error, then calls the catch function.
+// Without a catch function, the error is passed to the route's ErrorHandler, so failures can be handled centrally.
+// Middleware in other packages should use this to report failures.
+func Catch(ctx *router.Context, fn CatchFunc, err error) {
+	ctx.Set(ctxError, err)
+
+	if fn != nil {
+		fn(ctx)
 		return
 	}
-	ctx.Set(ctxError, err)
-	fn(ctx)
+
+	if r := ctx.Route(); r != nil {
+		if errorHandler := r.ErrorHandler(); errorHandler != nil {
+			errorHandler(ctx, err)
+		}
+	}
 }
 
 // RecoverFunc is a function called if a handler is recovered
 type RecoverFunc func(ctx *router.Context, v interface{})
 
+// PanicError is passed to the route's ErrorHandler when Recoverer recovers a panic without a RecoverFunc
+type PanicError struct {
+	Value interface{}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("recovered from panic: %v", e.Value)
+}
+
 // Recoverer is a middleware to catch panics inside calls.
 // Usually, this is best to handle to make sure your code is working right, HOWEVER
 // this is useful to catch errors and log them instead of fatally erroring.
+// If rec is nil, a PanicError is passed to the route's ErrorHandler.
 func Recoverer(rec RecoverFunc) router.MiddlewareFunc {
 	return func(fn router.Handler) router.Handler {
 		return func(ctx *router.Context) {
 			defer func() {
 				if r := recover(); r != nil {
+					if rec == nil {
+						Catch(ctx, nil, &PanicError{Value: r})
+						return
+					}
+
 					rec(ctx, r)
 				}
 			}()
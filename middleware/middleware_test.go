@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"meow.tf/astral/router"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// callRoute calls a route with the middleware, returning the error passed to the route's ErrorHandler
+func callRoute(t *testing.T, ctx *router.Context, m router.MiddlewareFunc) (handled error, called bool) {
+	r := router.New().SetErrorHandler(func(ctx *router.Context, err error) {
+		handled = err
+	})
+
+	r.Use(m)
+
+	rt := r.On("test", func(ctx *router.Context) {
+		called = true
+	})
+
+	rt.Call(ctx)
+
+	return
+}
+
+func newTestContext() *router.Context {
+	return &router.Context{
+		VariableBag: router.NewVariableBag(),
+		Guild: &discord.Guild{ID: 5, Roles: []discord.Role{
+			{ID: 5, Permissions: discord.PermissionSendMessages},
+			{ID: 10, Permissions: discord.PermissionManageMessages},
+		}},
+		Channel: &discord.Channel{ID: 6, GuildID: 5, Type: discord.GuildText},
+		User:    discord.User{ID: 8},
+		Event:   &gateway.MessageCreateEvent{Member: &discord.Member{}},
+	}
+}
+
+func TestCatch(t *testing.T) {
+	var caught error
+
+	ctx := newTestContext()
+
+	handled, called := callRoute(t, ctx, RequireNSFW(func(ctx *router.Context) {
+		caught = Error(ctx)
+	}))
+
+	if caught != ErrChannelNotNSFW || handled != nil || called {
+		t.Fatal("Expected the catch function to get the error, got:", caught, handled, called)
+	}
+
+	handled, called = callRoute(t, newTestContext(), RequireNSFW(nil))
+
+	if handled != ErrChannelNotNSFW || called {
+		t.Fatal("Expected the error handler to get the error, got:", handled, called)
+	}
+}
+
+func TestChannelType(t *testing.T) {
+	handled, called := callRoute(t, newTestContext(), ChannelType(discord.GuildVoice, nil))
+
+	var typeErr *ChannelTypeError
+
+	if !errors.Is(handled, ErrChannelType) || !errors.As(handled, &typeErr) || typeErr.Actual != discord.GuildText || called {
+		t.Fatal("Expected channel type error, got:", handled, called)
+	}
+
+	if handled, called = callRoute(t, newTestContext(), ChannelType(discord.GuildText, nil)); handled != nil || !called {
+		t.Fatal("Expected the route to run, got:", handled)
+	}
+}
+
+func TestPermission(t *testing.T) {
+	handled, called := callRoute(t, newTestContext(), Permission(discord.PermissionManageMessages|discord.PermissionSendMessages))
+
+	var permErr *router.MissingPermissionsError
+
+	if !errors.Is(handled, ErrMissingPermissions) || !errors.As(handled, &permErr) || permErr.Missing != discord.PermissionManageMessages || called {
+		t.Fatal("Expected missing Manage Messages, got:", handled, called)
+	}
+
+	ctx := newTestContext()
+	ctx.Event.Member.RoleIDs = []discord.RoleID{10}
+
+	if handled, called = callRoute(t, ctx, Permission(discord.PermissionManageMessages)); handled != nil || !called {
+		t.Fatal("Expected the route to run, got:", handled)
+	}
+
+	ctx.Guild = nil
+
+	if handled, _ = callRoute(t, ctx, Permission(discord.PermissionManageMessages)); handled != ErrGuildOnly {
+		t.Fatal("Expected guild only error, got:", handled)
+	}
+}
+
+func TestPermissionWithCatch(t *testing.T) {
+	var caught error
+
+	handled, called := callRoute(t, newTestContext(), PermissionWithCatch(discord.PermissionManageMessages, func(ctx *router.Context) {
+		caught = Error(ctx)
+	}))
+
+	if !errors.Is(caught, ErrMissingPermissions) || handled != nil || called {
+		t.Fatal("Expected the catch function to get the missing permissions, got:", caught, handled, called)
+	}
+}
+
+func TestRecoverer(t *testing.T) {
+	handled, _ := callRoute(t, newTestContext(), func(fn router.Handler) router.Handler {
+		return Recoverer(nil)(func(ctx *router.Context) {
+			panic("oops")
+		})
+	})
+
+	if handled == nil || handled.Error() != "recovered from panic: oops" {
+		t.Fatal("Expected panic error, got:", handled)
+	}
+}
+
+func TestBotPermission(t *testing.T) {
+	s := state.New("Bot test")
+	s.Client.Client.Client = httpdriver.WrapClient(http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.Header().Set("Content-Type", "application/json")
+
+			switch r.URL.Path {
+			case "/api/v9/users/@me":
+				rec.WriteString(`{"id":"99"}`)
+			case "/api/v9/guilds/5/members/99":
+				rec.WriteString(`{"user":{"id":"99"},"roles":["10"]}`)
+			default:
+				rec.WriteHeader(http.StatusNotFound)
+			}
+
+			return rec.Result(), nil
+		}),
+	})
+
+	ctx := newTestContext()
+	ctx.Session = s
+	ctx.Channel.Overwrites = []discord.Overwrite{
+		{ID: 5, Type: discord.OverwriteRole, Deny: discord.PermissionSendMessages},
+	}
+
+	handled, called := callRoute(t, ctx, BotPermission(discord.PermissionManageMessages|discord.PermissionSendMessages|discord.PermissionEmbedLinks, nil))
+
+	var botErr *BotPermissionsError
+
+	if !errors.Is(handled, ErrBotMissingPermissions) || !errors.As(handled, &botErr) || called {
+		t.Fatal("Expected bot permissions error, got:", handled, called)
+	}
+
+	if botErr.Missing != discord.PermissionSendMessages|discord.PermissionEmbedLinks {
+		t.Fatal("Expected Send Messages and Embed Links to be missing, got:", botErr)
+	}
+
+	if botErr.Error() != "the bot is missing permissions: Send Messages, Embed Links" {
+		t.Fatal("Unexpected error message:", botErr)
+	}
+
+	if handled, called = callRoute(t, ctx, BotPermission(discord.PermissionManageMessages, nil)); handled != nil || !called {
+		t.Fatal("Expected the route to run, got:", handled)
+	}
+}
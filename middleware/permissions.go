@@ -1,23 +1,37 @@
 package middleware
 
 import (
+	"errors"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"meow.tf/astral/router"
+	"strings"
 )
 
-// Permission validates the permission level
+// Errors
+var (
+	ErrBotMissingPermissions = errors.New("the bot is missing permissions")
+)
+
+// Permission validates the permission level, passing failures to the route's ErrorHandler.
+// Route.RequirePermissions also exports the permissions with the command.
 func Permission(permission discord.Permissions) router.MiddlewareFunc {
+	return PermissionWithCatch(permission, nil)
+}
+
+// PermissionWithCatch validates the permission level, calling "catch" if set when it fails.
+// Failures are a *router.MissingPermissionsError with the missing permissions, or ErrGuildOnly in direct messages.
+func PermissionWithCatch(permission discord.Permissions, catch CatchFunc) router.MiddlewareFunc {
 	return func(fn router.Handler) router.Handler {
 		return func(ctx *router.Context) {
-			member, err := ctx.Session.Member(ctx.Guild.ID, ctx.User.ID)
+			p, err := ctx.Permissions()
 
 			if err != nil {
+				Catch(ctx, catch, err)
 				return
 			}
 
-			p := discord.CalcOverwrites(*ctx.Guild, *ctx.Channel, *member)
-
-			if !p.Has(permission) {
+			if missing := permission &^ p; missing != 0 {
+				Catch(ctx, catch, &router.MissingPermissionsError{Missing: missing})
 				return // No permission
 			}
 
@@ -25,3 +39,69 @@ func Permission(permission discord.Permissions) router.MiddlewareFunc {
 		}
 	}
 }
+
+// BotPermissionsError is returned when the bot is missing permissions, and matches ErrBotMissingPermissions
+type BotPermissionsError struct {
+	Missing discord.Permissions
+}
+
+// Error lists the names of the missing permissions
+func (e *BotPermissionsError) Error() string {
+	return "the bot is missing permissions: " + strings.Join(router.PermissionNames(e.Missing), ", ")
+}
+
+// Is matches ErrBotMissingPermissions
+func (e *BotPermissionsError) Is(target error) bool {
+	return target == ErrBotMissingPermissions
+}
+
+// BotPermission requires the bot to have permissions in the channel, such as to send embeds or manage roles,
+// so commands fail with the missing permissions instead of an error from the API.
+// Failures are a *BotPermissionsError. Direct messages aren't checked, as there are no permissions to calculate.
+func BotPermission(permission discord.Permissions, catch CatchFunc) router.MiddlewareFunc {
+	return func(fn router.Handler) router.Handler {
+		return func(ctx *router.Context) {
+			if ctx.Guild == nil {
+				fn(ctx)
+				return
+			}
+
+			p, err := botPermissions(ctx)
+
+			if err != nil {
+				Catch(ctx, catch, err)
+				return
+			}
+
+			if missing := permission &^ p; missing != 0 {
+				Catch(ctx, catch, &BotPermissionsError{Missing: missing})
+				return
+			}
+
+			fn(ctx)
+		}
+	}
+}
+
+// botPermissions calculates the bot's permissions in the current channel, including overwrites
+func botPermissions(ctx *router.Context) (discord.Permissions, error) {
+	me, err := ctx.Session.Me()
+
+	if err != nil {
+		return 0, err
+	}
+
+	member, err := ctx.Session.Member(ctx.Guild.ID, me.ID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	channel := discord.Channel{}
+
+	if ctx.Channel != nil {
+		channel = *ctx.Channel
+	}
+
+	return discord.CalcOverwrites(*ctx.Guild, channel, *member), nil
+}
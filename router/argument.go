@@ -22,6 +22,10 @@ type StringChoice struct {
 // Argument type contains defined arguments, parsed from the command signature
 type Argument struct {
 	autocomplete AutocompleteHandler
+	// position is the argument's position in the signature, including attachments
+	position int
+	// Index is the argument's index in the text arguments.
+	// Attachments aren't text arguments, so their index is their position among the message's attachments.
 	Index        int
 	Name         string
 	Description  string
@@ -30,6 +34,12 @@ type Argument struct {
 	Choices      []StringChoice
 	Min          interface{}
 	Max          interface{}
+	MinLength    int
+	MaxLength    int
+	ChannelTypes []discord.ChannelType
+
+	NameLocalizations        map[string]string
+	DescriptionLocalizations map[string]string
 }
 
 // Autocomplete registers an autocomplete handler for this argument
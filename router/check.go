@@ -0,0 +1,23 @@
+package router
+
+// Check is a condition which must pass for a route to run, returning an error if it fails.
+// Unlike middleware, checks can be evaluated without running the route, such as to hide routes in help.
+type Check func(*Context) error
+
+// Check adds checks to this route. All sub-routes added afterwards will also inherit these checks.
+// Failed checks stop the route from running, and are passed to the route's ErrorHandler.
+func (r *Route) Check(checks ...Check) *Route {
+	r.checks = append(r.checks, checks...)
+	return r
+}
+
+// CanRun evaluates the route's checks, returning the first failure.
+func (r *Route) CanRun(ctx *Context) error {
+	for _, check := range r.checks {
+		if err := check(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
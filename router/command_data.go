@@ -0,0 +1,124 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"strconv"
+	"strings"
+)
+
+// CommandData is the data to create or edit an application command.
+// It extends api.CreateCommandData with the fields arikawa doesn't support yet, such as localizations
+// and permissions, which are filled in from the route tree when marshalled.
+type CommandData struct {
+	api.CreateCommandData
+
+	route *Route
+}
+
+// MarshalJSON marshals the command data, adding the route tree's localizations and the command's permissions
+func (c CommandData) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(c.CreateCommandData)
+
+	if err != nil || c.route == nil {
+		return b, err
+	}
+
+	var v map[string]interface{}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	localizeRouteJSON(v, c.route)
+
+	if p := c.route.DefaultMemberPermissions; p != nil {
+		// Permissions are a string, as they can exceed the safe integer range
+		v["default_member_permissions"] = strconv.FormatUint(uint64(*p), 10)
+	}
+
+	if c.route.DMPermission != nil {
+		v["dm_permission"] = *c.route.DMPermission
+	}
+
+	return json.Marshal(v)
+}
+
+// localizeRouteJSON adds a route's localizations to its command or subcommand JSON, then to its options
+func localizeRouteJSON(v map[string]interface{}, r *Route) {
+	setLocalizations(v, r.NameLocalizations, r.DescriptionLocalizations)
+
+	options, _ := v["options"].([]interface{})
+
+	for _, o := range options {
+		option, ok := o.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		name, _ := option["name"].(string)
+
+		if sub, exists := r.routes[name]; exists {
+			localizeRouteJSON(option, sub)
+			continue
+		}
+
+		for _, arg := range r.Arguments {
+			if optionName(arg.Name) == name {
+				setLocalizations(option, arg.NameLocalizations, arg.DescriptionLocalizations)
+				break
+			}
+		}
+	}
+}
+
+func setLocalizations(v map[string]interface{}, names, descriptions map[string]string) {
+	if len(names) > 0 {
+		v["name_localizations"] = names
+	}
+
+	if len(descriptions) > 0 {
+		v["description_localizations"] = descriptions
+	}
+}
+
+// optionName converts an argument name into a valid option name
+func optionName(name string) string {
+	return strings.ToLower(commandNameRe.ReplaceAllString(strings.ToLower(name), ""))
+}
+
+// commandsEndpoint returns the endpoint for global commands, or guild commands if the guild ID is valid
+func commandsEndpoint(appID discord.AppID, guildID discord.GuildID) string {
+	endpoint := api.EndpointApplications + appID.String()
+
+	if guildID.IsValid() {
+		endpoint += "/guilds/" + guildID.String()
+	}
+
+	return endpoint + "/commands"
+}
+
+// createCommand creates a global or guild command
+func createCommand(s *state.State, appID discord.AppID, guildID discord.GuildID, data CommandData) (*discord.Command, error) {
+	var cmd *discord.Command
+
+	return cmd, s.RequestJSON(&cmd, "POST", commandsEndpoint(appID, guildID), httputil.WithJSONBody(data))
+}
+
+// editCommand edits a global or guild command
+func editCommand(s *state.State, appID discord.AppID, guildID discord.GuildID, id discord.CommandID, data CommandData) (*discord.Command, error) {
+	var cmd *discord.Command
+
+	return cmd, s.RequestJSON(&cmd, "PATCH", commandsEndpoint(appID, guildID)+"/"+id.String(), httputil.WithJSONBody(data))
+}
+
+// overwriteCommands overwrites all global or guild commands
+func overwriteCommands(s *state.State, appID discord.AppID, guildID discord.GuildID, data []CommandData) ([]discord.Command, error) {
+	var cmds []discord.Command
+
+	return cmds, s.RequestJSON(&cmds, "PUT", commandsEndpoint(appID, guildID), httputil.WithJSONBody(data))
+}
@@ -0,0 +1,221 @@
+package router
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"regexp"
+)
+
+var (
+	ErrNotComponent = errors.New("interaction is not a component interaction or modal submission")
+
+	componentParamRe = regexp.MustCompile("{([^}]+)}")
+)
+
+// ComponentHandler is a handler for component (button/select menu) interactions.
+type ComponentHandler func(*ComponentContext)
+
+// ComponentContext is the context for component interactions and modal submissions.
+// It contains the custom ID, the parameters parsed from it, selected values for select menus
+// and submitted text input values for modals.
+type ComponentContext struct {
+	*Context
+
+	CustomID string
+	Params   map[string]string
+	Values   []string
+	Fields   map[string]string
+}
+
+// Param returns a parameter parsed from the custom ID
+func (c *ComponentContext) Param(name string) string {
+	return c.Params[name]
+}
+
+// UpdateMessage updates the message the component is attached to
+func (c *ComponentContext) UpdateMessage(b *ReplyBuilder) error {
+	return c.responder.(*InteractionResponder).UpdateMessage(b)
+}
+
+// DeferredMessageUpdate acknowledges the interaction, allowing the message to be updated later
+func (c *ComponentContext) DeferredMessageUpdate() error {
+	return c.responder.(*InteractionResponder).DeferredMessageUpdate()
+}
+
+// customIDPattern matches custom IDs against a pattern with named parameters
+type customIDPattern struct {
+	pattern string
+	re      *regexp.Regexp
+	params  []string
+}
+
+// compileCustomID compiles a custom ID pattern such as vote:{pollID}:{choice}
+func compileCustomID(pattern string) customIDPattern {
+	params := make([]string, 0)
+
+	expr := "^"
+	last := 0
+
+	for _, m := range componentParamRe.FindAllStringSubmatchIndex(pattern, -1) {
+		expr += regexp.QuoteMeta(pattern[last:m[0]]) + "(.+?)"
+		params = append(params, pattern[m[2]:m[3]])
+		last = m[1]
+	}
+
+	expr += regexp.QuoteMeta(pattern[last:]) + "$"
+
+	return customIDPattern{
+		pattern: pattern,
+		re:      regexp.MustCompile(expr),
+		params:  params,
+	}
+}
+
+// match checks a custom ID against the pattern, returning the parsed parameters.
+func (p customIDPattern) match(customID string) (map[string]string, bool) {
+	m := p.re.FindStringSubmatch(customID)
+
+	if m == nil {
+		return nil, false
+	}
+
+	params := make(map[string]string, len(p.params))
+
+	for i, name := range p.params {
+		params[name] = m[i+1]
+	}
+
+	return params, true
+}
+
+// ComponentRoute is a component handler matching a custom ID pattern
+type ComponentRoute struct {
+	customIDPattern
+	handler    ComponentHandler
+	middleware []MiddlewareFunc
+	modal      *Route
+}
+
+// Pattern returns the custom ID pattern for this route
+func (r *ComponentRoute) Pattern() string {
+	return r.pattern
+}
+
+// Use applies middleware to this component route.
+func (r *ComponentRoute) Use(f ...MiddlewareFunc) *ComponentRoute {
+	r.middleware = append(r.middleware, f...)
+	return r
+}
+
+// Call executes the component route, applying middleware.
+// Modal submissions are validated against the modal's inputs first.
+func (r *ComponentRoute) Call(ctx *ComponentContext) {
+	if r.modal != nil {
+		if err := r.modal.prepareModal(ctx); err != nil {
+			ctx.ReplyEphemeral(err.Error())
+			return
+		}
+	}
+
+	handler := func(*Context) {
+		r.handler(ctx)
+	}
+
+	for _, v := range r.middleware {
+		handler = v(handler)
+	}
+
+	handler(ctx.Context)
+}
+
+// ComponentRouter routes component interactions and modal submissions by their custom ID.
+type ComponentRouter struct {
+	routes     []*ComponentRoute
+	modals     []*ComponentRoute
+	middleware []MiddlewareFunc
+}
+
+// NewComponentRouter creates a new, empty component router.
+func NewComponentRouter() *ComponentRouter {
+	return &ComponentRouter{
+		routes:     make([]*ComponentRoute, 0),
+		modals:     make([]*ComponentRoute, 0),
+		middleware: make([]MiddlewareFunc, 0),
+	}
+}
+
+// Use applies middleware to the router. Routes added afterwards will inherit this middleware.
+func (r *ComponentRouter) Use(f ...MiddlewareFunc) *ComponentRouter {
+	r.middleware = append(r.middleware, f...)
+	return r
+}
+
+// On adds a handler for a custom ID pattern.
+// Patterns can contain named parameters, such as:
+//  	vote:{pollID}:{choice}
+// Parameters are available through ComponentContext.Param.
+// Routes are matched in the order they are added.
+func (r *ComponentRouter) On(pattern string, f ComponentHandler) *ComponentRoute {
+	rt := &ComponentRoute{
+		customIDPattern: compileCustomID(pattern),
+		handler:         f,
+		middleware:      append([]MiddlewareFunc{}, r.middleware...),
+	}
+
+	r.routes = append(r.routes, rt)
+
+	return rt
+}
+
+// Find finds the route matching a custom ID, returning the parsed parameters.
+func (r *ComponentRouter) Find(customID string) (*ComponentRoute, map[string]string) {
+	for _, rt := range r.routes {
+		if params, ok := rt.match(customID); ok {
+			return rt, params
+		}
+	}
+
+	return nil, nil
+}
+
+// ContextFromComponent creates a new ComponentContext from a component interaction or modal submission event
+func ContextFromComponent(state *state.State, event *gateway.InteractionCreateEvent, params map[string]string) (*ComponentContext, error) {
+	var customID string
+	var values []string
+	var fields map[string]string
+
+	switch data := event.Data.(type) {
+	case *discord.SelectInteraction:
+		customID = string(data.CustomID)
+		values = data.Values
+	case discord.ComponentInteraction:
+		customID = string(data.ID())
+	case *discord.ModalInteraction:
+		customID = string(data.CustomID)
+		fields = modalFields(data)
+	default:
+		return nil, ErrNotComponent
+	}
+
+	ctx, err := newInteractionContext(state, event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Components have no arguments, modal routes replace this route with their own
+	ctx.route = New()
+	ctx.route.Name = customID
+	ctx.route.Usage = customID
+	ctx.Command = customID
+
+	return &ComponentContext{
+		Context:  ctx,
+		CustomID: customID,
+		Params:   params,
+		Values:   values,
+		Fields:   fields,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"strings"
+	"testing"
+)
+
+func TestComponentRouter_Find(t *testing.T) {
+	r := NewComponentRouter()
+
+	r.On("vote:{pollID}:{choice}", func(ctx *ComponentContext) {})
+	r.On("close", func(ctx *ComponentContext) {})
+
+	match, params := r.Find("vote:123:yes")
+
+	if match == nil || match.Pattern() != "vote:{pollID}:{choice}" {
+		t.Fatal("Expected vote route to match")
+	}
+
+	if params["pollID"] != "123" || params["choice"] != "yes" {
+		t.Fatal("Expected pollID 123 and choice yes, got:", params)
+	}
+
+	if match, _ := r.Find("close"); match == nil || match.Pattern() != "close" {
+		t.Fatal("Expected close route to match")
+	}
+
+	if match, _ := r.Find("vote:123"); match != nil {
+		t.Fatal("Expected no route to match, got:", match.Pattern())
+	}
+}
+
+func TestComponentRoute_Call(t *testing.T) {
+	var calls []string
+
+	r := NewComponentRouter().Use(func(fn Handler) Handler {
+		return func(ctx *Context) {
+			calls = append(calls, "middleware")
+			fn(ctx)
+		}
+	})
+
+	r.On("button:{id}", func(ctx *ComponentContext) {
+		calls = append(calls, "handler:"+ctx.Param("id"))
+
+		if err := ctx.UpdateMessage(NewReply().Text("Clicked!")); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	match, params := r.Find("button:5")
+
+	ctx, api := newTestInteractionContext(t, New())
+
+	match.Call(&ComponentContext{Context: ctx, CustomID: "button:5", Params: params})
+
+	if len(calls) != 2 || calls[0] != "middleware" || calls[1] != "handler:5" {
+		t.Fatal("Expected middleware and handler to be called, got:", calls)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 1 || !strings.Contains(requests[0].Body, `"type":7`) {
+		t.Fatal("Expected the message to be updated, got:", requests)
+	}
+}
+
+func TestContextFromComponent_Arg(t *testing.T) {
+	s, _ := newTestState(t)
+
+	ctx, err := ContextFromComponent(s, &gateway.InteractionCreateEvent{
+		InteractionEvent: discord.InteractionEvent{
+			ID:        3,
+			ChannelID: 6,
+			Token:     "token",
+			User:      &discord.User{ID: 8},
+			Data:      &discord.ButtonInteraction{CustomID: "button:5"},
+		},
+	}, map[string]string{"id": "5"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewComponentRouter()
+
+	r.On("button:{id}", func(ctx *ComponentContext) {
+		if arg := ctx.Arg("missing"); arg != "" {
+			t.Fatal("Expected no argument, got:", arg)
+		}
+
+		if _, ok := ctx.IntArg("missing"); ok {
+			t.Fatal("Expected no value")
+		}
+
+		if _, err := ctx.Usage(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	match, _ := r.Find("button:5")
+
+	match.Call(ctx)
+}
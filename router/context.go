@@ -7,7 +7,9 @@ import (
 	"io"
 )
 
+// Responder sends responses for a context, either as messages or interaction responses.
 type Responder interface {
+	Defer(ephemeral bool) error
 	Usage(usage ...string) (*discord.Message, error)
 	Send(text string) (*discord.Message, error)
 	Sendf(format string, a ...interface{}) (*discord.Message, error)
@@ -15,8 +17,13 @@ type Responder interface {
 	Reply(text string) (*discord.Message, error)
 	Replyf(format string, a ...interface{}) (*discord.Message, error)
 	ReplyTo(to discord.UserID, text string) (*discord.Message, error)
+	ReplyEphemeral(text string) (*discord.Message, error)
+	ReplyComplex(b *ReplyBuilder) (*discord.Message, error)
 	ReplyEmbed(embed *discord.Embed) (*discord.Message, error)
 	ReplyFile(name string, r io.Reader) (*discord.Message, error)
+	EditResponse(text string) (*discord.Message, error)
+	DeleteResponse() error
+	EditFollowup(id discord.MessageID, text string) (*discord.Message, error)
 }
 
 // Context is the base "context" object.
@@ -32,14 +39,25 @@ type Context struct {
 	Channel        *discord.Channel
 	Message        discord.Message
 	User           discord.User
+	TargetUser     *discord.User
+	TargetMember   *discord.Member
+	TargetMessage  *discord.Message
 	Prefix         string
 	Command        string
 	ArgumentString string
 	Arguments      []string
 	ArgumentCount  int
+	values         map[string]interface{}
+	locale         string
+	guildLocale    string
 	responder      Responder
 }
 
+// Route returns the route being called
+func (c *Context) Route() *Route {
+	return c.route
+}
+
 // ContextFrom creates a new MessageContext from the session and event
 func ContextFrom(state *state.State, event *gateway.MessageCreateEvent, r *Route, args []string, argString string) (*Context, error) {
 	// Find the channel for the event, which doesn't have a built-in discordgo equivalent of .Guild()
@@ -75,7 +93,7 @@ func ContextFrom(state *state.State, event *gateway.MessageCreateEvent, r *Route
 		ArgumentString: argString,
 	}
 
-	ctx.responder = &MessageResponder{ctx}
+	ctx.responder = &MessageResponder{ctx: ctx}
 
 	return ctx, nil
 }
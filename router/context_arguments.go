@@ -2,14 +2,17 @@ package router
 
 import (
 	"github.com/diamondburned/arikawa/v3/discord"
-	emoji "github.com/tmdvs/Go-Emoji-Utils"
-	"strconv"
 )
 
-// Find the specified argument nand return the information and value
+// Find the specified argument nand return the information and value.
+// Contexts without arguments, such as button and select menu contexts, have no values.
 func (c *Context) arg(name string) (*Argument, string) {
+	if c.route == nil || c.route.Arguments == nil {
+		return nil, ""
+	}
+
 	if arg, exists := c.route.Arguments[name]; exists {
-		if arg.Index > c.ArgumentCount-1 {
+		if arg.Type == ArgumentTypeAttachment || arg.Index > c.ArgumentCount-1 {
 			return arg, ""
 		}
 
@@ -19,162 +22,176 @@ func (c *Context) arg(name string) (*Argument, string) {
 	panic("undefined argument " + name)
 }
 
-// Arg finds and returns a named argument as a string
-func (c *Context) Arg(name string) string {
-	_, val := c.arg(name)
+// setValue stores a typed argument value
+func (c *Context) setValue(name string, v interface{}) {
+	if c.values == nil {
+		c.values = make(map[string]interface{})
+	}
 
-	return val
+	c.values[name] = v
 }
 
-// IntArg finds and returns a named int argument
-func (c *Context) IntArg(name string) int64 {
+// Value finds and returns the typed value of a named argument.
+// Values are set by validation, arguments which weren't validated are parsed on first access.
+func (c *Context) Value(name string) (interface{}, bool) {
+	if v, exists := c.values[name]; exists {
+		return v, true
+	}
+
 	arg, val := c.arg(name)
 
-	if arg.Type != ArgumentTypeInt {
-		panic("Trying to use a non-int argument as int")
+	if arg == nil || val == "" {
+		return nil, false
 	}
 
-	v, err := strconv.ParseInt(val, 10, 64)
+	v, err := parseArgument(c, arg, val)
 
 	if err != nil {
-		return -1
+		return nil, false
 	}
 
-	return v
+	c.setValue(name, v)
+
+	return v, true
 }
 
-// FloatArg finds and returns a named float argument
-func (c *Context) FloatArg(name string) float64 {
-	arg, val := c.arg(name)
+// Arg finds and returns a named argument as a string
+func (c *Context) Arg(name string) string {
+	_, val := c.arg(name)
 
-	if arg.Type != ArgumentTypeFloat {
-		panic("Trying to use a non-float argument as float")
-	}
+	return val
+}
 
-	v, err := strconv.ParseFloat(val, 64)
+// IntArg finds and returns a named int argument
+func (c *Context) IntArg(name string) (int64, bool) {
+	v, ok := c.Value(name)
 
-	if err != nil {
-		return -1
+	if !ok {
+		return 0, false
 	}
 
-	return v
+	i, ok := v.(int64)
+
+	return i, ok
 }
 
-// BoolArg finds and returns a named bool argument
-func (c *Context) BoolArg(name string) bool {
-	arg, val := c.arg(name)
+// FloatArg finds and returns a named float argument
+func (c *Context) FloatArg(name string) (float64, bool) {
+	v, ok := c.Value(name)
 
-	if arg.Type != ArgumentTypeBool {
-		panic("Trying to use a non-bool argument as bool")
+	if !ok {
+		return 0, false
 	}
 
-	v, err := strconv.ParseBool(val)
+	f, ok := v.(float64)
 
-	if err != nil {
-		return false
-	}
-
-	return v
+	return f, ok
 }
 
-// UserArg finds and returns a named User argument
-func (c *Context) UserArg(name string) *discord.User {
-	arg, val := c.arg(name)
+// BoolArg finds and returns a named bool argument
+func (c *Context) BoolArg(name string) (bool, bool) {
+	v, ok := c.Value(name)
 
-	if arg.Type != ArgumentTypeUserMention {
-		panic("Trying to use a non-user argument as user")
+	if !ok {
+		return false, false
 	}
 
-	m := userMentionRegexp.FindStringSubmatch(val)
+	b, ok := v.(bool)
 
-	if m == nil {
-		return nil
-	}
+	return b, ok
+}
 
-	sf, err := discord.ParseSnowflake(m[1])
+// UserArg finds and returns a named User argument
+func (c *Context) UserArg(name string) (discord.User, bool) {
+	v, ok := c.Value(name)
 
-	if err != nil {
-		return nil
+	if !ok {
+		return discord.User{}, false
 	}
 
-	u, err := c.Session.User(discord.UserID(sf))
+	u, ok := v.(discord.User)
 
-	if err != nil {
-		return nil
-	}
-
-	return u
+	return u, ok
 }
 
 // ChannelArg finds and returns a named Channel argument
-func (c *Context) ChannelArg(name string) *discord.Channel {
+func (c *Context) ChannelArg(name string) (discord.Channel, bool) {
 	return c.ChannelArgType(name, 255)
 }
 
 // ChannelArgType finds and returns Channel argument with a specified type
-func (c *Context) ChannelArgType(name string, t discord.ChannelType) *discord.Channel {
-	arg, val := c.arg(name)
+func (c *Context) ChannelArgType(name string, t discord.ChannelType) (discord.Channel, bool) {
+	v, ok := c.Value(name)
 
-	if arg.Type != ArgumentTypeChannelMention {
-		panic("Trying to use a non-channel argument as channel")
+	if !ok {
+		return discord.Channel{}, false
 	}
 
-	m := channelMentionRegexp.FindStringSubmatch(val)
+	ch, ok := v.(discord.Channel)
 
-	if m != nil {
-		sf, err := discord.ParseSnowflake(m[1])
+	if !ok || t != 255 && ch.Type != t {
+		return discord.Channel{}, false
+	}
 
-		if err != nil {
-			return nil
-		}
+	return ch, true
+}
 
-		c, err := c.Session.Channel(discord.ChannelID(sf))
+// EmojiArg finds and returns an argument as an emoji
+func (c *Context) EmojiArg(name string) (discord.Emoji, bool) {
+	v, ok := c.Value(name)
 
-		if err != nil {
-			return nil
-		}
+	if !ok {
+		return discord.Emoji{}, false
+	}
 
-		if t != 255 && c.Type != t {
-			return nil
-		}
+	e, ok := v.(discord.Emoji)
 
-		return c
-	}
+	return e, ok
+}
 
-	return nil
+// Mentionable is the value of a mentionable argument, which is either a user or a role
+type Mentionable struct {
+	ID   discord.Snowflake
+	User *discord.User
+	Role *discord.Role
 }
 
-// EmojiArg finds and returns an argument as an emoji
-func (c *Context) EmojiArg(name string) *discord.Emoji {
-	arg, val := c.arg(name)
+// RoleArg finds and returns a named Role argument
+func (c *Context) RoleArg(name string) (discord.Role, bool) {
+	v, ok := c.Value(name)
 
-	if arg.Type != ArgumentTypeEmoji {
-		panic("Trying to use a non-emoji argument as emoji")
+	if !ok {
+		return discord.Role{}, false
 	}
 
-	m := emojiRegexp.FindStringSubmatch(val)
+	r, ok := v.(discord.Role)
 
-	if m != nil {
-		sf, err := discord.ParseSnowflake(m[3])
+	return r, ok
+}
 
-		if err != nil {
-			return nil
-		}
+// MentionableArg finds and returns a named mentionable (user or role) argument
+func (c *Context) MentionableArg(name string) (Mentionable, bool) {
+	v, ok := c.Value(name)
 
-		return &discord.Emoji{
-			ID:       discord.EmojiID(sf),
-			Name:     m[2],
-			Animated: m[1] == "a",
-		}
+	if !ok {
+		return Mentionable{}, false
 	}
 
-	result, err := emoji.LookupEmoji(val)
+	m, ok := v.(Mentionable)
 
-	if err == nil {
-		return &discord.Emoji{
-			Name: result.Value,
-		}
+	return m, ok
+}
+
+// AttachmentArg finds and returns a named attachment argument.
+// Attachments from interactions only contain the ID, as arikawa doesn't decode resolved attachments yet.
+func (c *Context) AttachmentArg(name string) (discord.Attachment, bool) {
+	v, ok := c.Value(name)
+
+	if !ok {
+		return discord.Attachment{}, false
 	}
 
-	return nil
+	a, ok := v.(discord.Attachment)
+
+	return a, ok
 }
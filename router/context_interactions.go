@@ -5,7 +5,6 @@ import (
 	"github.com/diamondburned/arikawa/v3/gateway"
 	"github.com/diamondburned/arikawa/v3/state"
 	"strconv"
-	"strings"
 )
 
 // FindInteraction finds a route path from a command interaction
@@ -25,7 +24,7 @@ func (r *Route) FindInteraction(parentRoute string, options []discord.CommandInt
 	var routeName string
 
 	for opts != nil {
-		routeName, opts = recurseOptions(opts)
+		routeName, opts = recurseOptions(currentRoute, opts)
 
 		if routeName != "" {
 			if newRoute, exists := currentRoute.routes[routeName]; exists {
@@ -39,11 +38,17 @@ func (r *Route) FindInteraction(parentRoute string, options []discord.CommandInt
 	return currentRoute
 }
 
-func recurseOptions(options []discord.CommandInteractionOption) (string, []discord.CommandInteractionOption) {
+// recurseOptions finds the subcommand or subcommand group option in options.
+// Options without a type are matched by the current route's sub route names.
+func recurseOptions(r *Route, options []discord.CommandInteractionOption) (string, []discord.CommandInteractionOption) {
 	for _, option := range options {
 		if option.Type == discord.SubcommandOptionType || option.Type == discord.SubcommandGroupOptionType {
 			return option.Name, option.Options
 		}
+
+		if _, exists := r.routes[option.Name]; exists && option.Type == 0 {
+			return option.Name, option.Options
+		}
 	}
 
 	return "", nil
@@ -87,7 +92,7 @@ func (r *Route) FindAutocomplete(parentRoute string, options []discord.Autocompl
 
 func recurseAutocompleteOptions(options []discord.AutocompleteOption) (string, []discord.AutocompleteOption, bool) {
 	for _, option := range options {
-		if option.Type == discord.SubcommandOptionType {
+		if option.Type == discord.SubcommandOptionType || option.Type == discord.SubcommandGroupOptionType {
 			return option.Name, option.Options, false
 		}
 
@@ -110,31 +115,79 @@ func recurseAutocompleteOptions(options []discord.AutocompleteOption) (string, [
 	return "", nil, false
 }
 
-// ContextFromInteraction creates a new Context from an interaction event
-func ContextFromInteraction(state *state.State, event *gateway.InteractionCreateEvent, r *Route) (*Context, error) {
-	// Find the guild for that channel. This uses State if enabled.
+// newInteractionContext creates a Context with the fields common to all interaction events
+func newInteractionContext(state *state.State, event *gateway.InteractionCreateEvent) (*Context, error) {
 	c, err := state.Channel(event.ChannelID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	g, err := state.Guild(event.GuildID)
+	var g *discord.Guild
+
+	if event.GuildID.IsValid() {
+		// Find the guild for that channel. This uses State if enabled.
+		g, err = state.Guild(event.GuildID)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx := &Context{
+		VariableBag: NewVariableBag(),
+
+		Session:     state,
+		Guild:       g,
+		Channel:     c,
+		Interaction: event,
+	}
+
+	if sender := event.Sender(); sender != nil {
+		ctx.User = *sender
+	}
+
+	if event.Message != nil {
+		ctx.Message = *event.Message
+	}
+
+	if l, ok := findInteractionLocales(event.ID); ok {
+		ctx.locale = l.Locale
+		ctx.guildLocale = l.GuildLocale
+	}
+
+	ctx.responder = &InteractionResponder{ctx: ctx}
+
+	return ctx, nil
+}
+
+// ContextFromInteraction creates a new Context from an interaction event
+func ContextFromInteraction(state *state.State, event *gateway.InteractionCreateEvent, r *Route) (*Context, error) {
+	ctx, err := newInteractionContext(state, event)
 
 	if err != nil {
 		return nil, err
 	}
 
 	args := make([]string, r.ArgumentCount)
+	values := make(map[string]interface{})
 
 	switch data := event.Data.(type) {
 	case *discord.CommandInteraction:
+		if r.commandType == discord.UserCommand || r.commandType == discord.MessageCommand {
+			if err := r.setTarget(ctx, data); err != nil {
+				return nil, err
+			}
+
+			break
+		}
+
 		path := r.Path()
 		path = path[1:]
 
 		for _, opt := range optionsFromPath(path, data.Options) {
 			for _, arg := range r.Arguments {
-				argName := strings.ToLower(commandNameRe.ReplaceAllString(strings.ToLower(arg.Name), ""))
+				argName := optionName(arg.Name)
 
 				if argName != opt.Name {
 					continue
@@ -151,6 +204,25 @@ func ContextFromInteraction(state *state.State, event *gateway.InteractionCreate
 					}
 
 					val = strconv.FormatInt(v, 10)
+					values[arg.Name] = v
+				case ArgumentTypeFloat:
+					v, err := opt.FloatValue()
+
+					if err != nil {
+						return nil, err
+					}
+
+					val = strconv.FormatFloat(v, 'f', -1, 64)
+					values[arg.Name] = v
+				case ArgumentTypeBool:
+					v, err := opt.BoolValue()
+
+					if err != nil {
+						return nil, err
+					}
+
+					val = strconv.FormatBool(v)
+					values[arg.Name] = v
 				case ArgumentTypeUserMention:
 					v, err := opt.SnowflakeValue()
 
@@ -158,7 +230,13 @@ func ContextFromInteraction(state *state.State, event *gateway.InteractionCreate
 						return nil, err
 					}
 
-					val = discord.UserID(v).Mention()
+					id := discord.UserID(v)
+
+					val = id.Mention()
+
+					if u, ok := data.Resolved.Users[id]; ok {
+						values[arg.Name] = u
+					}
 				case ArgumentTypeChannelMention:
 					v, err := opt.SnowflakeValue()
 
@@ -166,13 +244,59 @@ func ContextFromInteraction(state *state.State, event *gateway.InteractionCreate
 						return nil, err
 					}
 
-					val = discord.ChannelID(v).Mention()
-				default:
-					val = opt.Value.String()
+					id := discord.ChannelID(v)
+
+					val = id.Mention()
+
+					if ch, err := state.Channel(id); err == nil {
+						values[arg.Name] = *ch
+					} else if ch, ok := data.Resolved.Channels[id]; ok {
+						values[arg.Name] = ch
+					}
+				case ArgumentTypeRole:
+					v, err := opt.SnowflakeValue()
+
+					if err != nil {
+						return nil, err
+					}
+
+					id := discord.RoleID(v)
+
+					val = id.Mention()
 
-					if val[0] == '"' && val[len(val)-1] == '"' {
-						val = val[1 : len(val)-1]
+					if role, ok := data.Resolved.Roles[id]; ok {
+						values[arg.Name] = role
 					}
+				case ArgumentTypeMentionable:
+					v, err := opt.SnowflakeValue()
+
+					if err != nil {
+						return nil, err
+					}
+
+					if u, ok := data.Resolved.Users[discord.UserID(v)]; ok {
+						val = u.ID.Mention()
+						values[arg.Name] = Mentionable{ID: v, User: &u}
+					} else if role, ok := data.Resolved.Roles[discord.RoleID(v)]; ok {
+						val = role.ID.Mention()
+						values[arg.Name] = Mentionable{ID: v, Role: &role}
+					} else {
+						val = v.String()
+					}
+				case ArgumentTypeAttachment:
+					v, err := opt.SnowflakeValue()
+
+					if err != nil {
+						return nil, err
+					}
+
+					// Resolved attachments aren't decoded by arikawa, so only the ID is known.
+					// Attachments aren't text arguments, so they only have a value.
+					values[arg.Name] = discord.Attachment{ID: discord.AttachmentID(v)}
+
+					continue
+				default:
+					val = opt.String()
 				}
 
 				args[arg.Index] = val
@@ -183,21 +307,10 @@ func ContextFromInteraction(state *state.State, event *gateway.InteractionCreate
 	case *discord.AutocompleteInteraction:
 	}
 
-	ctx := &Context{
-		VariableBag: NewVariableBag(),
-
-		route:          r,
-		Session:        state,
-		Guild:          g,
-		Channel:        c,
-		User:           event.Member.User,
-		Arguments:      args,
-		ArgumentCount:  len(args),
-		Interaction:    event,
-		ArgumentString: "",
-	}
-
-	ctx.responder = &InteractionResponder{ctx}
+	ctx.route = r
+	ctx.Arguments = args
+	ctx.ArgumentCount = len(args)
+	ctx.values = values
 
 	return ctx, nil
 }
@@ -0,0 +1,117 @@
+package router
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+)
+
+var (
+	ErrNoTarget = errors.New("context menu interaction has no resolved target")
+)
+
+// OnUserCommand adds a handler for a user context menu command.
+// Unlike On, the name is used as-is, and can contain spaces and capital letters.
+// The targeted user (and member, in guilds) is available as Context.TargetUser and Context.TargetMember.
+func (r *Route) OnUserCommand(name string, f Handler) *Route {
+	return r.handleMenu(discord.UserCommand, name, wrapHandler(f))
+}
+
+// OnMessageCommand adds a handler for a message context menu command.
+// Unlike On, the name is used as-is, and can contain spaces and capital letters.
+// The targeted message is available as Context.TargetMessage.
+func (r *Route) OnMessageCommand(name string, f Handler) *Route {
+	return r.handleMenu(discord.MessageCommand, name, wrapHandler(f))
+}
+
+// handleMenu adds a context menu route of the specified command type.
+// Context menu commands only exist as application commands, so they are always exported.
+func (r *Route) handleMenu(t discord.CommandType, name string, f HandlerFunc) *Route {
+	rt := New()
+	rt.parent = r
+	rt.handler = f
+	rt.export = true
+	rt.commandType = t
+	rt.checks = append(rt.checks, r.checks...)
+	rt.DefaultMemberPermissions = r.DefaultMemberPermissions
+	rt.DMPermission = r.DMPermission
+	rt.Name = name
+	rt.Usage = name
+
+	if r.menus[t] == nil {
+		r.menus[t] = make(map[string]*Route)
+	}
+
+	r.menus[t][name] = rt.UseError(r.middleware...)
+	return rt
+}
+
+// CommandType returns the application command type of this route
+func (r *Route) CommandType() discord.CommandType {
+	if r.commandType == 0 {
+		return discord.ChatInputCommand
+	}
+
+	return r.commandType
+}
+
+// FindMenu finds a user or message context menu route by name
+func (r *Route) FindMenu(t discord.CommandType, name string) *Route {
+	return r.menus[t][name]
+}
+
+// FindCommand finds the route for a command interaction, including context menu commands.
+func (r *Route) FindCommand(data *discord.CommandInteraction) *Route {
+	if t := menuType(data); t != 0 {
+		if rt := r.FindMenu(t, data.Name); rt != nil {
+			return rt
+		}
+	}
+
+	return r.FindInteraction(data.Name, data.Options)
+}
+
+// menuType guesses the type of a context menu interaction.
+// Command interactions don't include their type, however context menu commands have no options
+// and resolve exactly their target, which chat input commands can only do through options.
+func menuType(data *discord.CommandInteraction) discord.CommandType {
+	if len(data.Options) > 0 {
+		return 0
+	}
+
+	switch {
+	case len(data.Resolved.Messages) == 1:
+		return discord.MessageCommand
+	case len(data.Resolved.Users) == 1:
+		return discord.UserCommand
+	}
+
+	return 0
+}
+
+// setTarget sets the targeted user or message of a context menu interaction on the context
+func (r *Route) setTarget(ctx *Context, data *discord.CommandInteraction) error {
+	switch r.commandType {
+	case discord.UserCommand:
+		for id, u := range data.Resolved.Users {
+			user := u
+			ctx.TargetUser = &user
+
+			if m, ok := data.Resolved.Members[id]; ok {
+				m.User = user
+				ctx.TargetMember = &m
+			}
+
+			return nil
+		}
+	case discord.MessageCommand:
+		for _, m := range data.Resolved.Messages {
+			msg := m
+			ctx.TargetMessage = &msg
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	return ErrNoTarget
+}
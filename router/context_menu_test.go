@@ -0,0 +1,85 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"testing"
+)
+
+func TestRoute_OnUserCommand(t *testing.T) {
+	var target *discord.User
+
+	r := New()
+
+	r.On("report <user>", func(ctx *Context) {}).Desc("Report something")
+
+	r.OnUserCommand("Report User", func(ctx *Context) {
+		target = ctx.TargetUser
+	})
+
+	data := &discord.CommandInteraction{Name: "Report User"}
+	data.Resolved.Users = map[discord.UserID]discord.User{9: {ID: 9, Username: "target"}}
+
+	match := r.FindCommand(data)
+
+	if match == nil || match.CommandType() != discord.UserCommand {
+		t.Fatal("Expected user command route, got:", match)
+	}
+
+	s, _ := newTestState(t)
+
+	ctx, err := ContextFromInteraction(s, &gateway.InteractionCreateEvent{
+		InteractionEvent: discord.InteractionEvent{ChannelID: 2, Data: data},
+	}, match)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := match.Call(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if target == nil || target.ID != 9 {
+		t.Fatal("Expected target user 9, got:", target)
+	}
+}
+
+func TestRoute_OnMessageCommand(t *testing.T) {
+	r := New()
+
+	r.OnMessageCommand("Bookmark", func(ctx *Context) {})
+
+	data := &discord.CommandInteraction{Name: "Bookmark"}
+	data.Resolved.Messages = map[discord.MessageID]discord.Message{10: {ID: 10, Content: "hello"}}
+
+	match := r.FindCommand(data)
+
+	if match == nil || match.CommandType() != discord.MessageCommand {
+		t.Fatal("Expected message command route, got:", match)
+	}
+
+	ctx := &Context{}
+
+	if err := match.setTarget(ctx, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if ctx.TargetMessage == nil || ctx.TargetMessage.Content != "hello" {
+		t.Fatal("Expected target message, got:", ctx.TargetMessage)
+	}
+
+	if err := match.setTarget(ctx, &discord.CommandInteraction{Name: "Bookmark"}); err != ErrNoTarget {
+		t.Fatal("Expected ErrNoTarget, got:", err)
+	}
+
+	cmd, err := match.toCommandData()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cmd.Type != discord.MessageCommand || cmd.Description != "" || len(cmd.Options) > 0 {
+		t.Fatal("Expected message command data without description or options, got:", cmd)
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"io"
 )
 
+func (c *Context) Defer(ephemeral bool) error {
+	return c.responder.Defer(ephemeral)
+}
+
 func (c *Context) Usage(usage ...string) (*discord.Message, error) {
 	return c.responder.Usage(usage...)
 }
@@ -33,6 +37,14 @@ func (c *Context) ReplyTo(to discord.UserID, text string) (*discord.Message, err
 	return c.responder.ReplyTo(to, text)
 }
 
+func (c *Context) ReplyEphemeral(text string) (*discord.Message, error) {
+	return c.responder.ReplyEphemeral(text)
+}
+
+func (c *Context) ReplyComplex(b *ReplyBuilder) (*discord.Message, error) {
+	return c.responder.ReplyComplex(b)
+}
+
 func (c *Context) ReplyEmbed(embed *discord.Embed) (*discord.Message, error) {
 	return c.responder.ReplyEmbed(embed)
 }
@@ -40,3 +52,15 @@ func (c *Context) ReplyEmbed(embed *discord.Embed) (*discord.Message, error) {
 func (c *Context) ReplyFile(name string, r io.Reader) (*discord.Message, error) {
 	return c.responder.ReplyFile(name, r)
 }
+
+func (c *Context) EditResponse(text string) (*discord.Message, error) {
+	return c.responder.EditResponse(text)
+}
+
+func (c *Context) DeleteResponse() error {
+	return c.responder.DeleteResponse()
+}
+
+func (c *Context) EditFollowup(id discord.MessageID, text string) (*discord.Message, error) {
+	return c.responder.EditFollowup(id, text)
+}
@@ -0,0 +1,305 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"meow.tf/astral/arguments"
+	"strings"
+	"time"
+)
+
+// NotFoundFunc is called when no route matches an event.
+// Event is either a *gateway.MessageCreateEvent or a *gateway.InteractionCreateEvent.
+type NotFoundFunc func(event interface{}, args []string)
+
+// ContextErrorFunc is called when a Context could not be built for a matched route.
+// Route is nil for component and modal interactions.
+// Event is either a *gateway.MessageCreateEvent or a *gateway.InteractionCreateEvent.
+type ContextErrorFunc func(event interface{}, route *Route, err error)
+
+// Dispatcher wires message and interaction events from a state to a route.
+type Dispatcher struct {
+	route *Route
+	state *state.State
+
+	// Prefixes resolves the valid text command prefixes for a message
+	Prefixes PrefixResolver
+	// MentionPrefix allows mentioning the bot (@Bot command) as a prefix
+	MentionPrefix bool
+	// NoPrefixDM allows commands in direct messages without any prefix
+	NoPrefixDM bool
+	// AllowBots allows commands from other bots. Messages from the bot itself are always ignored.
+	AllowBots bool
+
+	// EphemeralFallback is how ephemeral replies to messages are sent, as messages don't support them
+	EphemeralFallback EphemeralFallbackType
+	// EphemeralDeleteAfter is how long ephemeral replies to messages are kept before deletion, DefaultEphemeralDeleteAfter if unset
+	EphemeralDeleteAfter time.Duration
+
+	// Components routes component interactions and modal submissions, if set
+	Components *ComponentRouter
+
+	// Suggest replies to unknown text commands with similar commands, such as "did you mean ping?"
+	Suggest bool
+	// SuggestDistance is the maximum edit distance of suggestions, 2 by default
+	SuggestDistance int
+	// SuppressSuggestions is called before suggesting, and can return true to suppress suggestions, such as per guild
+	SuppressSuggestions func(evt *gateway.MessageCreateEvent) bool
+
+	NotFound     NotFoundFunc
+	ContextError ContextErrorFunc
+}
+
+// NewDispatcher creates a new dispatcher for the route, using the specified text prefixes.
+func NewDispatcher(s *state.State, r *Route, prefixes ...string) *Dispatcher {
+	return &Dispatcher{
+		route:    r,
+		state:    s,
+		Prefixes: StaticPrefixes(prefixes),
+	}
+}
+
+// Attach adds the dispatcher's event handlers to the state.
+// The returned function removes them.
+func (d *Dispatcher) Attach() func() {
+	rmMessage := d.state.AddHandler(d.HandleMessage)
+	rmInteraction := d.state.AddHandler(d.HandleInteraction)
+
+	return func() {
+		rmMessage()
+		rmInteraction()
+	}
+}
+
+// HandleMessage finds and calls the route for a message event.
+// Messages from bots are ignored unless AllowBots is set, and the bot's own messages are always ignored.
+func (d *Dispatcher) HandleMessage(evt *gateway.MessageCreateEvent) {
+	if evt.Author.Bot && !d.AllowBots || d.isSelf(evt.Author.ID) {
+		return
+	}
+
+	prefix, str, ok := d.resolvePrefix(evt)
+
+	if !ok {
+		return
+	}
+
+	args := arguments.Parse(str)
+
+	match := d.route.Find(args...)
+
+	if match == nil {
+		d.suggest(evt, args)
+		d.notFound(evt, args)
+		return
+	}
+
+	command, args, argString := splitCommand(str, args, len(match.Path()))
+
+	ctx, err := ContextFrom(d.state, evt, match, args, argString)
+
+	if err != nil {
+		d.contextError(evt, match, err)
+		return
+	}
+
+	ctx.Prefix = prefix
+	ctx.Command = command
+
+	if r, ok := ctx.responder.(*MessageResponder); ok {
+		r.EphemeralFallback = d.EphemeralFallback
+		r.EphemeralDeleteAfter = d.EphemeralDeleteAfter
+	}
+
+	go match.Call(ctx)
+}
+
+// isSelf checks if a user is the bot itself
+func (d *Dispatcher) isSelf(id discord.UserID) bool {
+	me, err := d.state.Me()
+
+	return err == nil && me.ID == id
+}
+
+// HandleInteraction finds and calls the route for a command, autocomplete, component or modal interaction.
+func (d *Dispatcher) HandleInteraction(evt *gateway.InteractionCreateEvent) {
+	switch data := evt.Data.(type) {
+	case *discord.CommandInteraction:
+		match := d.route.FindCommand(data)
+
+		if match == nil {
+			d.notFound(evt, []string{data.Name})
+			return
+		}
+
+		ctx, err := ContextFromInteraction(d.state, evt, match)
+
+		if err != nil {
+			d.contextError(evt, match, err)
+			return
+		}
+
+		ctx.Command = strings.Join(match.Path(), " ")
+
+		go match.Call(ctx)
+	case *discord.AutocompleteInteraction:
+		match, opts := d.route.FindAutocomplete(data.Name, data.Options)
+
+		if match == nil {
+			d.notFound(evt, []string{data.Name})
+			return
+		}
+
+		ctx, err := ContextFromInteraction(d.state, evt, match)
+
+		if err != nil {
+			d.contextError(evt, match, err)
+			return
+		}
+
+		go match.CallAutocomplete(ctx, opts)
+	case discord.ComponentInteraction:
+		if d.Components == nil {
+			return
+		}
+
+		customID := string(data.ID())
+
+		match, params := d.Components.Find(customID)
+
+		if match == nil {
+			d.notFound(evt, []string{customID})
+			return
+		}
+
+		ctx, err := ContextFromComponent(d.state, evt, params)
+
+		if err != nil {
+			d.contextError(evt, nil, err)
+			return
+		}
+
+		go match.Call(ctx)
+	case *discord.ModalInteraction:
+		if d.Components == nil {
+			return
+		}
+
+		customID := string(data.CustomID)
+
+		match, params := d.Components.FindModal(customID)
+
+		if match == nil {
+			d.notFound(evt, []string{customID})
+			return
+		}
+
+		ctx, err := ContextFromComponent(d.state, evt, params)
+
+		if err != nil {
+			d.contextError(evt, nil, err)
+			return
+		}
+
+		go match.Call(ctx)
+	}
+}
+
+// resolvePrefix finds the prefix used in a message, returning it and the message content following it.
+func (d *Dispatcher) resolvePrefix(evt *gateway.MessageCreateEvent) (string, string, bool) {
+	var prefixes []string
+
+	if d.Prefixes != nil {
+		prefixes = d.Prefixes.Prefixes(evt)
+	}
+
+	if d.MentionPrefix {
+		if me, err := d.state.Me(); err == nil {
+			mentions := mentionPrefixes(me.ID)
+
+			if prefix, str, ok := matchPrefix(evt.Content, mentions); ok {
+				return prefix, strings.TrimSpace(str), true
+			}
+		}
+	}
+
+	if prefix, str, ok := matchPrefix(evt.Content, prefixes); ok {
+		return prefix, str, true
+	}
+
+	if d.NoPrefixDM && !evt.GuildID.IsValid() {
+		return "", evt.Content, true
+	}
+
+	return "", "", false
+}
+
+func (d *Dispatcher) notFound(event interface{}, args []string) {
+	if d.NotFound != nil {
+		d.NotFound(event, args)
+	}
+}
+
+// suggest replies with commands similar to an unknown command, if enabled
+func (d *Dispatcher) suggest(evt *gateway.MessageCreateEvent, args []string) {
+	if !d.Suggest || len(args) == 0 || d.SuppressSuggestions != nil && d.SuppressSuggestions(evt) {
+		return
+	}
+
+	distance := d.SuggestDistance
+
+	if distance <= 0 {
+		distance = 2
+	}
+
+	suggestions := d.route.Suggest(args, distance)
+
+	if len(suggestions) == 0 {
+		return
+	}
+
+	if len(suggestions) > 3 {
+		suggestions = suggestions[:3]
+	}
+
+	// Messages are translated with a context, which only needs the route and guild for the locale
+	ctx := &Context{route: d.route}
+
+	if evt.GuildID.IsValid() {
+		ctx.Guild, _ = d.state.Guild(evt.GuildID)
+	}
+
+	command := strings.Join(args[:len(strings.Fields(suggestions[0]))], " ")
+
+	d.state.SendMessageReply(evt.ChannelID, ctx.T(MessageSuggestion, command, "`"+strings.Join(suggestions, "`, `")+"`"), evt.ID)
+}
+
+func (d *Dispatcher) contextError(event interface{}, r *Route, err error) {
+	if d.ContextError != nil {
+		d.ContextError(event, r, err)
+	}
+}
+
+// splitCommand splits the parsed arguments into the command (the first level arguments)
+// and the remaining arguments, as well as the raw argument string following the command.
+func splitCommand(str string, args []string, level int) (string, []string, string) {
+	if level > len(args) {
+		level = len(args)
+	}
+
+	argString := strings.TrimSpace(str)
+
+	for i := 0; i < level; i++ {
+		idx := strings.IndexAny(argString, " \t\n")
+
+		if idx == -1 {
+			argString = ""
+			break
+		}
+
+		argString = strings.TrimSpace(argString[idx+1:])
+	}
+
+	return strings.Join(args[:level], " "), args[level:], argString
+}
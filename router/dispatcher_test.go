@@ -0,0 +1,113 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"strings"
+	"testing"
+)
+
+func TestSplitCommand(t *testing.T) {
+	str := "nesting level1 \"quoted value\" other"
+	args := []string{"nesting", "level1", "quoted value", "other"}
+
+	command, rest, argString := splitCommand(str, args, 2)
+
+	if command != "nesting level1" {
+		t.Fatal("Expected command to be nesting level1, got:", command)
+	}
+
+	if len(rest) != 2 || rest[0] != "quoted value" || rest[1] != "other" {
+		t.Fatal("Expected remaining arguments to be [quoted value, other], got:", rest)
+	}
+
+	if argString != "\"quoted value\" other" {
+		t.Fatal("Expected argument string to be \"quoted value\" other, got:", argString)
+	}
+}
+
+func TestSplitCommand_NoArguments(t *testing.T) {
+	command, rest, argString := splitCommand("ping", []string{"ping"}, 1)
+
+	if command != "ping" {
+		t.Fatal("Expected command to be ping, got:", command)
+	}
+
+	if len(rest) != 0 {
+		t.Fatal("Expected no remaining arguments, got:", rest)
+	}
+
+	if argString != "" {
+		t.Fatal("Expected empty argument string, got:", argString)
+	}
+}
+
+func TestDispatcher_Suggest(t *testing.T) {
+	r := New()
+	r.On("ping", nil)
+
+	s, api := newTestState(t)
+
+	d := NewDispatcher(s, r, "!")
+	d.Suggest = true
+
+	evt := &gateway.MessageCreateEvent{
+		Message: discord.Message{ID: 7, ChannelID: 6, Content: "!pnig"},
+	}
+
+	d.HandleMessage(evt)
+
+	requests := api.Requests()
+
+	if len(requests) != 1 || requests[0].Path != "/api/v9/channels/6/messages" {
+		t.Fatal("Expected a suggestion to be sent, got:", requests)
+	}
+
+	if !strings.Contains(requests[0].Body, "Unknown command `pnig`, did you mean `ping`?") {
+		t.Fatal("Expected suggestion for ping, got:", requests[0].Body)
+	}
+
+	d.SuppressSuggestions = func(evt *gateway.MessageCreateEvent) bool {
+		return true
+	}
+
+	d.HandleMessage(evt)
+
+	if requests := api.Requests(); len(requests) != 1 {
+		t.Fatal("Expected suggestions to be suppressed, got:", requests)
+	}
+}
+
+func TestDispatcher_IgnoreBots(t *testing.T) {
+	r := New()
+	r.On("ping", nil)
+
+	s, api := newTestState(t)
+
+	d := NewDispatcher(s, r, "!")
+	d.Suggest = true
+	d.NoPrefixDM = true
+
+	// The bot's own messages are ignored, even when they look like commands
+	d.HandleMessage(&gateway.MessageCreateEvent{
+		Message: discord.Message{ID: 7, ChannelID: 6, Content: "!pnig", Author: discord.User{ID: 1, Bot: true}},
+	})
+
+	other := &gateway.MessageCreateEvent{
+		Message: discord.Message{ID: 7, ChannelID: 6, Content: "!pnig", Author: discord.User{ID: 9, Bot: true}},
+	}
+
+	d.HandleMessage(other)
+
+	if requests := api.Requests(); len(requests) != 0 {
+		t.Fatal("Expected bot messages to be ignored, got:", requests)
+	}
+
+	d.AllowBots = true
+
+	d.HandleMessage(other)
+
+	if requests := api.Requests(); len(requests) != 1 {
+		t.Fatal("Expected other bots to be allowed, got:", requests)
+	}
+}
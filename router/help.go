@@ -0,0 +1,320 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// helpCustomID is the custom ID pattern for help page buttons.
+// The command is the escaped prefix and the route's path separated by a space, so it's never empty.
+const helpCustomID = "astral.help:{page}:{command}"
+
+// HelpOptions configures the help route
+type HelpOptions struct {
+	// PageSize is the number of commands per page, 10 by default
+	PageSize int
+	// Color is the embed color
+	Color discord.Color
+	// Components enables buttons to switch pages, which are routed through this component router.
+	// Without it, every page is sent at once.
+	Components *ComponentRouter
+}
+
+// help renders help embeds for a route tree
+type help struct {
+	root *Route
+	opts HelpOptions
+}
+
+// Help adds a help [command] route, which lists the commands the caller can run, or shows the detail of a command.
+// Commands are specified by their path, such as help admin roles add. Routes with failing checks are hidden.
+func (r *Route) Help(opts HelpOptions) *Route {
+	if opts.PageSize <= 0 {
+		opts.PageSize = 10
+	}
+
+	h := &help{root: r, opts: opts}
+
+	if opts.Components != nil {
+		opts.Components.On(helpCustomID, h.page)
+	}
+
+	return r.Handle("help [command]", h.handle).Desc("Shows the available commands").Argument("command", func(arg *Argument) {
+		arg.Description = "The command to show details for"
+	})
+}
+
+// handle replies with the command list, or the detail of the specified command
+func (h *help) handle(ctx *Context) error {
+	path := strings.Fields(strings.Join(ctx.Arguments, " "))
+
+	rt := h.find(ctx, path)
+
+	if rt == nil {
+		_, err := ctx.ReplyEphemeral(ctx.T(MessageHelpNotFound, strings.Join(path, " ")))
+		return err
+	}
+
+	// Routes with sub routes are groups, which are listed like the root
+	if rt == h.root || len(rt.routes) > 0 && rt.handler == nil {
+		pages := h.pages(ctx, rt)
+
+		b := NewReply().Embed(pages[0])
+
+		if h.opts.Components == nil {
+			b.Embeds = pages
+
+			if len(b.Embeds) > 10 {
+				b.Embeds = b.Embeds[:10]
+			}
+		} else if len(pages) > 1 {
+			b.Component(h.buttons(ctx, rt, 0, len(pages)))
+		}
+
+		_, err := ctx.ReplyComplex(b)
+		return err
+	}
+
+	embed := h.detail(ctx, rt)
+
+	_, err := ctx.ReplyEmbed(&embed)
+	return err
+}
+
+// page switches to another page of a listing
+func (h *help) page(ctx *ComponentContext) {
+	// Component contexts have a placeholder route, so messages are translated through the help route's tree
+	ctx.route = h.root
+
+	page, err := strconv.Atoi(ctx.Param("page"))
+
+	if err != nil {
+		return
+	}
+
+	command := ctx.Param("command")
+	idx := strings.Index(command, " ")
+
+	if idx == -1 {
+		return
+	}
+
+	// Pages are shown with the prefix the listing was invoked with
+	if ctx.Prefix, err = url.QueryUnescape(command[:idx]); err != nil {
+		return
+	}
+
+	rt := h.find(ctx.Context, strings.Fields(command[idx+1:]))
+
+	if rt == nil {
+		return
+	}
+
+	pages := h.pages(ctx.Context, rt)
+
+	if page < 0 || page >= len(pages) {
+		return
+	}
+
+	ctx.UpdateMessage(NewReply().Embed(pages[page]).Component(h.buttons(ctx.Context, rt, page, len(pages))))
+}
+
+// find finds a route by path, following aliases. Routes the caller can't run aren't found.
+func (h *help) find(ctx *Context, path []string) *Route {
+	rt := h.root
+
+	for _, name := range path {
+		name = strings.ToLower(name)
+
+		if alias, ok := rt.aliases[name]; ok {
+			name = alias
+		}
+
+		sub, ok := rt.routes[name]
+
+		if !ok || sub.CanRun(ctx) != nil {
+			return nil
+		}
+
+		rt = sub
+	}
+
+	return rt
+}
+
+// visibleRoutes returns the sub routes the caller can run, sorted by name
+func (h *help) visibleRoutes(ctx *Context, r *Route) []*Route {
+	routes := make([]*Route, 0, len(r.routes))
+
+	for _, rt := range r.sortedRoutes() {
+		if rt.CanRun(ctx) == nil {
+			routes = append(routes, rt)
+		}
+	}
+
+	return routes
+}
+
+// pages renders the listing of a route's sub routes into pages of embeds
+func (h *help) pages(ctx *Context, r *Route) []discord.Embed {
+	routes := h.visibleRoutes(ctx, r)
+
+	title := ctx.T(MessageHelpTitle)
+
+	if r != h.root {
+		title = h.command(ctx, r)
+	}
+
+	count := (len(routes) + h.opts.PageSize - 1) / h.opts.PageSize
+
+	if count == 0 {
+		count = 1
+	}
+
+	pages := make([]discord.Embed, count)
+
+	for i := range pages {
+		lines := make([]string, 0, h.opts.PageSize)
+
+		for j := i * h.opts.PageSize; j < len(routes) && j < (i+1)*h.opts.PageSize; j++ {
+			line := "`" + h.usage(ctx, routes[j]) + "`"
+
+			if routes[j].Description != "" {
+				line += " - " + routes[j].Description
+			}
+
+			lines = append(lines, line)
+		}
+
+		pages[i] = discord.Embed{
+			Title:       title,
+			Description: strings.Join(lines, "\n"),
+			Color:       h.opts.Color,
+		}
+
+		if count > 1 {
+			pages[i].Footer = &discord.EmbedFooter{Text: ctx.T(MessageHelpPage, i+1, count)}
+		}
+	}
+
+	return pages
+}
+
+// buttons creates the previous and next page buttons, which keep the prefix the listing was invoked with
+func (h *help) buttons(ctx *Context, r *Route, page, count int) discord.ContainerComponent {
+	path := strings.Join(r.Path(), " ")
+
+	if r == h.root {
+		path = ""
+	}
+
+	command := url.QueryEscape(h.prefix(ctx)) + " " + path
+
+	customID := func(page int) discord.ComponentID {
+		return discord.ComponentID("astral.help:" + strconv.Itoa(page) + ":" + command)
+	}
+
+	return &discord.ActionRowComponent{
+		&discord.ButtonComponent{Label: "<", CustomID: customID(page - 1), Style: discord.SecondaryButtonStyle(), Disabled: page == 0},
+		&discord.ButtonComponent{Label: ">", CustomID: customID(page + 1), Style: discord.SecondaryButtonStyle(), Disabled: page >= count-1},
+	}
+}
+
+// detail renders the detail of a single command
+func (h *help) detail(ctx *Context, r *Route) discord.Embed {
+	embed := discord.Embed{
+		Title:       h.usage(ctx, r),
+		Description: r.Description,
+		Color:       h.opts.Color,
+	}
+
+	if args := r.sortedArguments(); len(args) > 0 {
+		lines := make([]string, len(args))
+
+		for i, arg := range args {
+			line := "`" + arg.Name + "` (" + arg.Type.String()
+
+			if !arg.Required {
+				line += ", " + ctx.T(MessageHelpOptional)
+			}
+
+			line += ")"
+
+			if arg.Description != "" {
+				line += " - " + arg.Description
+			}
+
+			if len(arg.Choices) > 0 {
+				choices := make([]string, len(arg.Choices))
+
+				for j, choice := range arg.Choices {
+					choices[j] = choice.Name
+				}
+
+				line += " [" + strings.Join(choices, ", ") + "]"
+			}
+
+			lines[i] = line
+		}
+
+		embed.Fields = append(embed.Fields, discord.EmbedField{Name: ctx.T(MessageHelpArguments), Value: strings.Join(lines, "\n")})
+	}
+
+	if r.parent != nil {
+		aliases := make([]string, 0)
+
+		for alias, name := range r.parent.aliases {
+			if name == r.Name {
+				aliases = append(aliases, alias)
+			}
+		}
+
+		if len(aliases) > 0 {
+			sort.Strings(aliases)
+
+			embed.Fields = append(embed.Fields, discord.EmbedField{Name: ctx.T(MessageHelpAliases), Value: strings.Join(aliases, ", ")})
+		}
+	}
+
+	if routes := h.visibleRoutes(ctx, r); len(routes) > 0 {
+		lines := make([]string, len(routes))
+
+		for i, rt := range routes {
+			lines[i] = "`" + h.usage(ctx, rt) + "`"
+		}
+
+		embed.Fields = append(embed.Fields, discord.EmbedField{Name: ctx.T(MessageHelpSubcommands), Value: strings.Join(lines, "\n")})
+	}
+
+	return embed
+}
+
+// prefix returns the prefix commands are shown with, which is / for slash commands
+func (h *help) prefix(ctx *Context) string {
+	if ctx.Interaction != nil {
+		if _, ok := ctx.Interaction.Data.(*discord.CommandInteraction); ok {
+			return "/"
+		}
+	}
+
+	return ctx.Prefix
+}
+
+// command returns the route's full command, including the prefix
+func (h *help) command(ctx *Context, r *Route) string {
+	return h.prefix(ctx) + strings.Join(r.Path(), " ")
+}
+
+// usage returns the route's full usage, including the prefix and parent routes
+func (h *help) usage(ctx *Context, r *Route) string {
+	usage := h.command(ctx, r)
+
+	if idx := strings.Index(r.Usage, " "); idx != -1 {
+		usage += r.Usage[idx:]
+	}
+
+	return usage
+}
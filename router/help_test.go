@@ -0,0 +1,151 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"strings"
+	"testing"
+)
+
+func testHelpRoute() (*Route, *Route) {
+	r := New()
+
+	r.On("ping", nil).Desc("Checks the bot is alive").Alias("p")
+
+	r.On("roll <count int> [sides int options:6,20]", nil).Desc("Rolls dice").Argument("count", func(arg *Argument) {
+		arg.Description = "Number of dice"
+	})
+
+	r.Group(func(g *Route) {
+		g.Check(func(ctx *Context) error {
+			return errors.New("admins only")
+		})
+
+		g.On("ban <@user>", nil).Desc("Bans a user")
+	})
+
+	return r, r.Help(HelpOptions{PageSize: 2})
+}
+
+func callHelp(t *testing.T, args ...string) string {
+	_, help := testHelpRoute()
+
+	ctx, api := newTestMessageContext(t, help)
+	ctx.Prefix = "!"
+	ctx.Arguments = args
+	ctx.ArgumentCount = len(args)
+
+	if err := help.Call(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 1 {
+		t.Fatal("Expected 1 request, got:", requests)
+	}
+
+	// Embeds are JSON encoded, which escapes < and >
+	return strings.NewReplacer(`\u003c`, "<", `\u003e`, ">").Replace(requests[0].Body)
+}
+
+func TestRoute_Help(t *testing.T) {
+	body := callHelp(t)
+
+	for _, expected := range []string{"`!ping` - Checks the bot is alive", "`!roll <count int>", "Page 1 of 2", "Page 2 of 2"} {
+		if !strings.Contains(body, expected) {
+			t.Fatal("Expected", expected, "in", body)
+		}
+	}
+
+	if strings.Contains(body, "ban") {
+		t.Fatal("Expected routes with failing checks to be hidden, got:", body)
+	}
+}
+
+func TestRoute_HelpDetail(t *testing.T) {
+	body := callHelp(t, "roll")
+
+	for _, expected := range []string{"`count` (integer) - Number of dice", "`sides` (integer, optional) [6, 20]"} {
+		if !strings.Contains(body, expected) {
+			t.Fatal("Expected", expected, "in", body)
+		}
+	}
+
+	if body = callHelp(t, "p"); !strings.Contains(body, `"Aliases","value":"p"`) {
+		t.Fatal("Expected ping's aliases, got:", body)
+	}
+
+	if body = callHelp(t, "ban"); !strings.Contains(body, "Unknown command ban.") {
+		t.Fatal("Expected hidden route to be unknown, got:", body)
+	}
+}
+
+func TestRoute_HelpPagesText(t *testing.T) {
+	components := NewComponentRouter()
+
+	r := New().SetTranslator(MapTranslator{
+		DefaultLocale: {MessageHelpPage: "Seite %d von %d"},
+	})
+
+	r.On("ping", nil).Desc("Checks the bot is alive")
+	r.On("roll", nil).Desc("Rolls dice")
+
+	help := r.Help(HelpOptions{PageSize: 2, Components: components})
+
+	ctx, api := newTestMessageContext(t, help)
+	ctx.Prefix = "!"
+
+	if err := help.Call(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var reply struct {
+		Components []struct {
+			Components []struct {
+				CustomID string `json:"custom_id"`
+			} `json:"components"`
+		} `json:"components"`
+	}
+
+	if err := json.Unmarshal([]byte(api.Requests()[0].Body), &reply); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(reply.Components) != 1 || len(reply.Components[0].Components) != 2 {
+		t.Fatal("Expected page buttons, got:", reply)
+	}
+
+	customID := reply.Components[0].Components[1].CustomID
+
+	match, params := components.Find(customID)
+
+	if match == nil {
+		t.Fatal("Expected the next page button to be routed, got:", customID)
+	}
+
+	componentCtx, err := ContextFromComponent(ctx.Session, &gateway.InteractionCreateEvent{
+		InteractionEvent: discord.InteractionEvent{
+			ID:        3,
+			ChannelID: 6,
+			Token:     "token",
+			User:      &discord.User{ID: 8},
+			Data:      &discord.ButtonInteraction{CustomID: discord.ComponentID(customID)},
+		},
+	}, params)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	match.Call(componentCtx)
+
+	requests := api.Requests()
+	body := requests[len(requests)-1].Body
+
+	if !strings.Contains(body, "`!roll` - Rolls dice") || !strings.Contains(body, "Seite 2 von 2") {
+		t.Fatal("Expected the translated second page with the text prefix, got:", body)
+	}
+}
@@ -0,0 +1,67 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"sync"
+	"time"
+)
+
+// interactionLocaleTTL is how long decoded locales are kept, which is as long as an interaction can be responded to
+const interactionLocaleTTL = 15 * time.Minute
+
+// interactionLocales are the locales sent with an interaction, which arikawa's InteractionEvent doesn't decode yet
+type interactionLocales struct {
+	Locale      string `json:"locale"`
+	GuildLocale string `json:"guild_locale"`
+}
+
+var (
+	localeMu    sync.Mutex
+	localeCache = make(map[discord.InteractionID]interactionLocales)
+)
+
+// DecodeInteraction decodes an interaction from its raw payload, such as the body of a request to an interactions endpoint.
+// The interaction's locale and guild locale are kept for ContextFromInteraction, as arikawa's events don't include them.
+// Interactions received through the gateway don't have them, and fall back to the guild's preferred locale.
+func DecodeInteraction(b []byte) (*gateway.InteractionCreateEvent, error) {
+	var event gateway.InteractionCreateEvent
+
+	if err := json.Unmarshal(b, &event); err != nil {
+		return nil, err
+	}
+
+	var l interactionLocales
+
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, err
+	}
+
+	storeInteractionLocales(event.ID, l)
+
+	return &event, nil
+}
+
+// storeInteractionLocales keeps the locales of an interaction until it expires
+func storeInteractionLocales(id discord.InteractionID, l interactionLocales) {
+	localeMu.Lock()
+	localeCache[id] = l
+	localeMu.Unlock()
+
+	time.AfterFunc(interactionLocaleTTL, func() {
+		localeMu.Lock()
+		delete(localeCache, id)
+		localeMu.Unlock()
+	})
+}
+
+// findInteractionLocales returns the decoded locales of an interaction
+func findInteractionLocales(id discord.InteractionID) (interactionLocales, bool) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+
+	l, ok := localeCache[id]
+
+	return l, ok
+}
@@ -1,12 +1,15 @@
 package router
 
 import (
+	"encoding/json"
 	"errors"
 	"github.com/diamondburned/arikawa/v3/api"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"github.com/diamondburned/arikawa/v3/state"
 	"github.com/diamondburned/arikawa/v3/utils/httputil"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -56,6 +59,30 @@ func (e argTypeError) Error() string {
 	return "invalid argument type for " + strings.Join(e.route.Path(), "->") + " arg " + e.arg.Name + ": " + strconv.Itoa(int(e.arg.Type))
 }
 
+type commandDepthError struct {
+	route *Route
+}
+
+func (e commandDepthError) Error() string {
+	return "command too deep at " + strings.Join(e.route.Path(), "->") + ": commands can only contain subcommand groups, which can only contain subcommands"
+}
+
+type commandArgumentsError struct {
+	route *Route
+}
+
+func (e commandArgumentsError) Error() string {
+	return "command " + strings.Join(e.route.Path(), "->") + " has both sub routes and arguments, which can't be exported"
+}
+
+type commandOptionCountError struct {
+	route *Route
+}
+
+func (e commandOptionCountError) Error() string {
+	return "command " + strings.Join(e.route.Path(), "->") + " has more than " + strconv.Itoa(maxCommandOptions) + " sub routes or arguments"
+}
+
 // RegisterCommands registers all sub routes as interaction/slash commands
 func RegisterCommands(r *Route, s *state.State, appID discord.AppID) ([]discord.Command, error) {
 	return RegisterGuildCommands(r, s, appID, discord.NullGuildID)
@@ -63,9 +90,20 @@ func RegisterCommands(r *Route, s *state.State, appID discord.AppID) ([]discord.
 
 // RegisterGuildCommands registers all sub routes as interaction/slash commands to a guild
 func RegisterGuildCommands(r *Route, s *state.State, appID discord.AppID, guildID discord.GuildID) ([]discord.Command, error) {
-	commands := make([]api.CreateCommandData, 0)
+	commands, err := exportedCommands(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return overwriteCommands(s, appID, guildID, commands)
+}
+
+// exportedCommands converts all exported sub routes and context menu commands into command data
+func exportedCommands(r *Route) ([]CommandData, error) {
+	commands := make([]CommandData, 0)
 
-	for _, sub := range r.routes {
+	for _, sub := range r.sortedRoutes() {
 		if !sub.export {
 			continue
 		}
@@ -79,17 +117,35 @@ func RegisterGuildCommands(r *Route, s *state.State, appID discord.AppID, guildI
 		commands = append(commands, data)
 	}
 
-	if guildID.IsValid() {
-		return s.BulkOverwriteGuildCommands(appID, guildID, commands)
+	for _, menus := range r.menus {
+		for _, sub := range menus {
+			data, err := sub.toCommandData()
+
+			if err != nil {
+				return nil, err
+			}
+
+			commands = append(commands, data)
+		}
 	}
 
-	return s.BulkOverwriteCommands(appID, commands)
+	return commands, nil
 }
 
-func (r *Route) toCommandData() (api.CreateCommandData, error) {
-	data := api.CreateCommandData{
-		Name:        r.Name,
-		Description: r.Description,
+func (r *Route) toCommandData() (CommandData, error) {
+	data := CommandData{
+		CreateCommandData: api.CreateCommandData{
+			Name:        r.Name,
+			Description: r.Description,
+		},
+		route: r,
+	}
+
+	if r.commandType == discord.UserCommand || r.commandType == discord.MessageCommand {
+		// Context menu commands have no description or options
+		data.Type = r.commandType
+		data.Description = ""
+		return data, nil
 	}
 
 	if r.Description == "" {
@@ -97,31 +153,10 @@ func (r *Route) toCommandData() (api.CreateCommandData, error) {
 	}
 
 	if len(r.routes) > 0 {
-		options := make([]discord.CommandOption, len(r.routes))
+		options, err := r.subcommandOptions()
 
-		i := 0
-
-		for _, route := range r.routes {
-			inputValues, err := argsFromRoute(route)
-
-			if err != nil {
-				return data, err
-			}
-
-			values := make([]discord.CommandOptionValue, len(inputValues))
-
-			for k, value := range inputValues {
-				values[k] = value.(discord.CommandOptionValue)
-			}
-
-			options[i] = &discord.SubcommandOption{
-				OptionName:  route.Name,
-				Options:     values,
-				Required:    false,
-				Description: route.Description,
-			}
-
-			i++
+		if err != nil {
+			return data, err
 		}
 
 		data.Options = options
@@ -146,11 +181,7 @@ func (r *Route) RegisterCommand(s *state.State, appID discord.AppID, guildID dis
 		return nil, err
 	}
 
-	if guildID != discord.NullGuildID {
-		return s.CreateGuildCommand(appID, guildID, data)
-	}
-
-	return s.CreateCommand(appID, data)
+	return createCommand(s, appID, guildID, data)
 }
 
 // UpdateCommand registers a single command, with sub routes as subcommands.
@@ -161,23 +192,169 @@ func (r *Route) UpdateCommand(s *state.State, appID discord.AppID, commandID dis
 		return nil, err
 	}
 
-	if guildID != discord.NullGuildID {
-		return s.EditGuildCommand(appID, guildID, commandID, data)
-	}
-
-	return s.EditCommand(appID, commandID, data)
+	return editCommand(s, appID, guildID, commandID, data)
 }
 
+// maxCommandOptions is the maximum number of options Discord allows on a command, group or subcommand
+const maxCommandOptions = 25
+
 var (
 	commandNameRe = regexp.MustCompile("[^\\w-]")
 )
 
+// subcommandOptions converts a route's sub routes into subcommands, or subcommand groups for sub routes with children.
+func (r *Route) subcommandOptions() ([]discord.CommandOption, error) {
+	if len(r.Arguments) > 0 {
+		return nil, commandArgumentsError{route: r}
+	}
+
+	if len(r.routes) > maxCommandOptions {
+		return nil, commandOptionCountError{route: r}
+	}
+
+	options := make([]discord.CommandOption, 0, len(r.routes))
+
+	for _, route := range r.sortedRoutes() {
+		if len(route.routes) > 0 {
+			group, err := route.subcommandGroupOption()
+
+			if err != nil {
+				return nil, err
+			}
+
+			options = append(options, group)
+			continue
+		}
+
+		sub, err := route.subcommandOption()
+
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, sub)
+	}
+
+	return options, nil
+}
+
+// subcommandGroupOption converts a route into a subcommand group.
+// Groups can only contain subcommands, so sub routes with children of their own are too deep.
+func (r *Route) subcommandGroupOption() (*discord.SubcommandGroupOption, error) {
+	if r.Description == "" {
+		return nil, commandDescriptionError{route: r}
+	}
+
+	if len(r.Arguments) > 0 {
+		return nil, commandArgumentsError{route: r}
+	}
+
+	if len(r.routes) > maxCommandOptions {
+		return nil, commandOptionCountError{route: r}
+	}
+
+	group := &discord.SubcommandGroupOption{
+		OptionName:  r.Name,
+		Description: r.Description,
+		Subcommands: make([]*discord.SubcommandOption, 0, len(r.routes)),
+	}
+
+	for _, route := range r.sortedRoutes() {
+		if len(route.routes) > 0 {
+			return nil, commandDepthError{route: route}
+		}
+
+		sub, err := route.subcommandOption()
+
+		if err != nil {
+			return nil, err
+		}
+
+		group.Subcommands = append(group.Subcommands, sub)
+	}
+
+	return group, nil
+}
+
+// subcommandOption converts a route and its arguments into a subcommand
+func (r *Route) subcommandOption() (*discord.SubcommandOption, error) {
+	if r.Description == "" {
+		return nil, commandDescriptionError{route: r}
+	}
+
+	args, err := argsFromRoute(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]discord.CommandOptionValue, len(args))
+
+	for i, arg := range args {
+		values[i] = arg.(discord.CommandOptionValue)
+	}
+
+	return &discord.SubcommandOption{
+		OptionName:  r.Name,
+		Description: r.Description,
+		Options:     values,
+	}, nil
+}
+
+// sortedRoutes returns the route's sub routes sorted by name
+func (r *Route) sortedRoutes() []*Route {
+	routes := make([]*Route, 0, len(r.routes))
+
+	for _, route := range r.routes {
+		routes = append(routes, route)
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Name < routes[j].Name
+	})
+
+	return routes
+}
+
+// AttachmentOptionType is the command option type for attachments, which arikawa doesn't define yet
+const AttachmentOptionType discord.CommandOptionType = 11
+
+// attachmentOption is an attachment command option.
+// It embeds UnknownCommandOption to satisfy discord.CommandOptionValue, and marshals like the built in options.
+type attachmentOption struct {
+	*discord.UnknownCommandOption
+	Description string
+	Required    bool
+}
+
+func newAttachmentOption(name, description string, required bool) *attachmentOption {
+	return &attachmentOption{
+		UnknownCommandOption: &discord.UnknownCommandOption{OptionName: name, OptionType: AttachmentOptionType},
+		Description:          description,
+		Required:             required,
+	}
+}
+
+// MarshalJSON marshals the attachment option with the "type" field.
+func (a *attachmentOption) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type        discord.CommandOptionType `json:"type"`
+		Name        string                    `json:"name"`
+		Description string                    `json:"description"`
+		Required    bool                      `json:"required"`
+	}{AttachmentOptionType, a.OptionName, a.Description, a.Required})
+}
+
 // argsFromRoute takes a route's arguments and translates them into a discord.CommandOption
 func argsFromRoute(r *Route) ([]discord.CommandOption, error) {
+	if len(r.Arguments) > maxCommandOptions {
+		return nil, commandOptionCountError{route: r}
+	}
+
 	options := make([]discord.CommandOption, len(r.Arguments))
 
 	for _, arg := range r.Arguments {
-		argName := strings.ToLower(commandNameRe.ReplaceAllString(strings.ToLower(arg.Name), ""))
+		argName := optionName(arg.Name)
 
 		if arg.Description == "" {
 			return nil, argDescriptionError{route: r, arg: arg}
@@ -199,7 +376,15 @@ func argsFromRoute(r *Route) ([]discord.CommandOption, error) {
 				opt.Choices = arg.integerChoices()
 			}
 
-			options[arg.Index] = opt
+			if min, ok := arg.Min.(int64); ok {
+				opt.Min = option.NewInt(int(min))
+			}
+
+			if max, ok := arg.Max.(int64); ok {
+				opt.Max = option.NewInt(int(max))
+			}
+
+			options[arg.position] = opt
 		case ArgumentTypeFloat:
 			opt := &discord.NumberOption{
 				OptionName:  argName,
@@ -215,25 +400,48 @@ func argsFromRoute(r *Route) ([]discord.CommandOption, error) {
 				opt.Choices = arg.numberChoices()
 			}
 
-			options[arg.Index] = opt
+			if min, ok := arg.Min.(float64); ok {
+				opt.Min = option.NewFloat(min)
+			}
+
+			if max, ok := arg.Max.(float64); ok {
+				opt.Max = option.NewFloat(max)
+			}
+
+			options[arg.position] = opt
 		case ArgumentTypeBool:
-			options[arg.Index] = &discord.BooleanOption{
+			options[arg.position] = &discord.BooleanOption{
 				OptionName:  argName,
 				Required:    arg.Required,
 				Description: arg.Description,
 			}
 		case ArgumentTypeUserMention:
-			options[arg.Index] = &discord.UserOption{
+			options[arg.position] = &discord.UserOption{
 				OptionName:  argName,
 				Required:    arg.Required,
 				Description: arg.Description,
 			}
 		case ArgumentTypeChannelMention:
-			options[arg.Index] = &discord.ChannelOption{
+			options[arg.position] = &discord.ChannelOption{
+				OptionName:   argName,
+				Required:     arg.Required,
+				Description:  arg.Description,
+				ChannelTypes: arg.ChannelTypes,
+			}
+		case ArgumentTypeRole:
+			options[arg.position] = &discord.RoleOption{
+				OptionName:  argName,
+				Required:    arg.Required,
+				Description: arg.Description,
+			}
+		case ArgumentTypeMentionable:
+			options[arg.position] = &discord.MentionableOption{
 				OptionName:  argName,
 				Required:    arg.Required,
 				Description: arg.Description,
 			}
+		case ArgumentTypeAttachment:
+			options[arg.position] = newAttachmentOption(argName, arg.Description, arg.Required)
 		case ArgumentTypeEmoji:
 			fallthrough
 		case ArgumentTypeBasic:
@@ -251,7 +459,7 @@ func argsFromRoute(r *Route) ([]discord.CommandOption, error) {
 				opt.Choices = arg.stringChoices()
 			}
 
-			options[arg.Index] = opt
+			options[arg.position] = opt
 		default:
 			return nil, argTypeError{route: r, arg: arg}
 		}
@@ -0,0 +1,107 @@
+package router
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"testing"
+)
+
+func testNestedRoute() *Route {
+	r := New()
+
+	admin := r.On("admin", nil).Desc("Admin commands")
+
+	admin.On("ping", nil).Desc("Ping")
+
+	roles := admin.On("roles", nil).Desc("Manage roles")
+
+	roles.On("add <name>", nil).Desc("Add a role").Argument("name", func(arg *Argument) {
+		arg.Description = "Role name"
+	})
+
+	roles.On("remove <name>", nil).Desc("Remove a role").Argument("name", func(arg *Argument) {
+		arg.Description = "Role name"
+	})
+
+	return admin
+}
+
+func TestRoute_toCommandDataNested(t *testing.T) {
+	data, err := testNestedRoute().toCommandData()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(data.Options) != 2 {
+		t.Fatal("Expected 2 options, got:", len(data.Options))
+	}
+
+	if sub, ok := data.Options[0].(*discord.SubcommandOption); !ok || sub.OptionName != "ping" {
+		t.Fatal("Expected ping subcommand, got:", data.Options[0])
+	}
+
+	group, ok := data.Options[1].(*discord.SubcommandGroupOption)
+
+	if !ok || group.OptionName != "roles" || len(group.Subcommands) != 2 {
+		t.Fatal("Expected roles subcommand group with 2 subcommands, got:", data.Options[1])
+	}
+
+	if group.Subcommands[0].OptionName != "add" || len(group.Subcommands[0].Options) != 1 {
+		t.Fatal("Expected add subcommand with 1 option, got:", group.Subcommands[0])
+	}
+}
+
+func TestRoute_toCommandDataTooDeep(t *testing.T) {
+	admin := testNestedRoute()
+
+	admin.routes["roles"].routes["add"].On("color", nil).Desc("Role color")
+
+	_, err := admin.toCommandData()
+
+	var depthErr commandDepthError
+
+	if !errors.As(err, &depthErr) || depthErr.route.Name != "add" {
+		t.Fatal("Expected depth error on add, got:", err)
+	}
+}
+
+func TestRoute_toCommandDataArguments(t *testing.T) {
+	r := New()
+
+	test := r.On("test <arg>", nil).Desc("Test")
+	test.On("sub", nil).Desc("Sub")
+
+	_, err := test.toCommandData()
+
+	var argsErr commandArgumentsError
+
+	if !errors.As(err, &argsErr) {
+		t.Fatal("Expected arguments error, got:", err)
+	}
+}
+
+func TestRoute_FindInteractionNested(t *testing.T) {
+	r := New()
+	r.Add(testNestedRoute())
+
+	match := r.FindInteraction("admin", []discord.CommandInteractionOption{
+		{
+			Type: discord.SubcommandGroupOptionType,
+			Name: "roles",
+			Options: []discord.CommandInteractionOption{
+				{
+					Type: discord.SubcommandOptionType,
+					Name: "remove",
+					Options: []discord.CommandInteractionOption{
+						{Type: discord.StringOptionType, Name: "name", Value: []byte(`"mod"`)},
+					},
+				},
+			},
+		},
+	})
+
+	if match == nil || match.Name != "remove" {
+		t.Fatal("Expected remove route, got:", match)
+	}
+}
@@ -0,0 +1,200 @@
+package router
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DefaultLocale is the locale used when no other locale is known, and the fallback for missing translations
+var DefaultLocale = "en-US"
+
+// Translator translates message keys into a locale.
+// Translate returns false if the key has no translation for the locale.
+type Translator interface {
+	Translate(locale, key string, args ...interface{}) (string, bool)
+}
+
+// MapTranslator is a Translator backed by format strings, by locale and key.
+type MapTranslator map[string]map[string]string
+
+// Translate formats the message for a key in the specified locale
+func (t MapTranslator) Translate(locale, key string, args ...interface{}) (string, bool) {
+	format, ok := t[locale][key]
+
+	if !ok {
+		return "", false
+	}
+
+	return fmt.Sprintf(format, args...), true
+}
+
+// Message keys used by the router, which can be translated.
+const (
+	MessageUsage              = "usage"
+	MessageRequired           = "validation.required"
+	MessageAttachmentRequired = "validation.attachment_required"
+	MessageInteger            = "validation.integer"
+	MessageFloat              = "validation.float"
+	MessageBool               = "validation.bool"
+	MessageEmoji              = "validation.emoji"
+	MessageUser               = "validation.user"
+	MessageChannel            = "validation.channel"
+	MessageChannelType        = "validation.channel_type"
+	MessageRole               = "validation.role"
+	MessageMentionable        = "validation.mentionable"
+	MessageMinInt             = "validation.min_int"
+	MessageMaxInt             = "validation.max_int"
+	MessageMinFloat           = "validation.min_float"
+	MessageMaxFloat           = "validation.max_float"
+	MessageMinLength          = "validation.min_length"
+	MessageMaxLength          = "validation.max_length"
+	MessageHelpTitle          = "help.title"
+	MessageHelpPage           = "help.page"
+	MessageHelpNotFound       = "help.not_found"
+	MessageHelpOptional       = "help.optional"
+	MessageHelpArguments      = "help.arguments"
+	MessageHelpAliases        = "help.aliases"
+	MessageHelpSubcommands    = "help.subcommands"
+	MessageSuggestion         = "suggestion"
+)
+
+// defaultMessages are the English messages used when a key isn't translated
+var defaultMessages = MapTranslator{
+	"": {
+		MessageUsage:              "Usage: %s",
+		MessageRequired:           "The %s argument is required.",
+		MessageAttachmentRequired: "The %s attachment is required.",
+		MessageInteger:            "%s must be an integer.",
+		MessageFloat:              "%s must be a floating point number.",
+		MessageBool:               "%s must be a true/false value.",
+		MessageEmoji:              "%s must be a valid emoji.",
+		MessageUser:               "%s must be a valid user.",
+		MessageChannel:            "%s must be a valid channel.",
+		MessageChannelType:        "%s must be a %s channel.",
+		MessageRole:               "%s must be a valid role.",
+		MessageMentionable:        "%s must be a valid user or role.",
+		MessageMinInt:             "%s must be at least %d.",
+		MessageMaxInt:             "%s must be at most %d.",
+		MessageMinFloat:           "%s must be at least %g.",
+		MessageMaxFloat:           "%s must be at most %g.",
+		MessageMinLength:          "%s must be at least %d characters.",
+		MessageMaxLength:          "%s must be at most %d characters.",
+		MessageHelpTitle:          "Commands",
+		MessageHelpPage:           "Page %d of %d",
+		MessageHelpNotFound:       "Unknown command %s.",
+		MessageHelpOptional:       "optional",
+		MessageHelpArguments:      "Arguments",
+		MessageHelpAliases:        "Aliases",
+		MessageHelpSubcommands:    "Sub-commands",
+		MessageSuggestion:         "Unknown command `%s`, did you mean %s?",
+	},
+}
+
+// SetTranslator sets the translator for this route's messages.
+// Sub-routes without their own translator will inherit it.
+func (r *Route) SetTranslator(t Translator) *Route {
+	r.translator = t
+	return r
+}
+
+// Translator returns the route's translator, walking up the parents if it's not set.
+func (r *Route) Translator() Translator {
+	for rt := r; rt != nil; rt = rt.parent {
+		if rt.translator != nil {
+			return rt.translator
+		}
+	}
+
+	return nil
+}
+
+// Localize sets the route's name and description for a locale, exported with the command.
+// Empty values are ignored.
+func (r *Route) Localize(locale, name, description string) *Route {
+	r.NameLocalizations, r.DescriptionLocalizations = localize(r.NameLocalizations, r.DescriptionLocalizations, locale, name, description)
+	return r
+}
+
+// Localize sets the argument's name and description for a locale, exported with the command.
+// Empty values are ignored.
+func (a *Argument) Localize(locale, name, description string) *Argument {
+	a.NameLocalizations, a.DescriptionLocalizations = localize(a.NameLocalizations, a.DescriptionLocalizations, locale, name, description)
+	return a
+}
+
+func localize(names, descriptions map[string]string, locale, name, description string) (map[string]string, map[string]string) {
+	if name != "" {
+		if names == nil {
+			names = make(map[string]string)
+		}
+
+		names[locale] = name
+	}
+
+	if description != "" {
+		if descriptions == nil {
+			descriptions = make(map[string]string)
+		}
+
+		descriptions[locale] = description
+	}
+
+	return names, descriptions
+}
+
+// SetLocale overrides the locale used for this context's messages, such as from guild settings
+func (c *Context) SetLocale(locale string) {
+	c.locale = locale
+}
+
+// Locale returns the locale for this context.
+// This is the locale set by SetLocale or the interaction, the user's locale, the guild's preferred locale or DefaultLocale, in that order.
+func (c *Context) Locale() string {
+	switch {
+	case c.locale != "":
+		return c.locale
+	case c.User.Locale != "":
+		return c.User.Locale
+	case c.guildLocale != "":
+		return c.guildLocale
+	case c.Guild != nil && c.Guild.PreferredLocale != "":
+		return c.Guild.PreferredLocale
+	}
+
+	return DefaultLocale
+}
+
+// T translates a message key into the context's locale, formatted with args.
+// Missing translations fall back to DefaultLocale, the built in English messages and finally the key.
+func (c *Context) T(key string, args ...interface{}) string {
+	var t Translator
+
+	if c.route != nil {
+		t = c.route.Translator()
+	}
+
+	if t != nil {
+		locale := c.Locale()
+
+		if str, ok := t.Translate(locale, key, args...); ok {
+			return str
+		}
+
+		if locale != DefaultLocale {
+			if str, ok := t.Translate(DefaultLocale, key, args...); ok {
+				return str
+			}
+		}
+	}
+
+	if str, ok := defaultMessages.Translate("", key, args...); ok {
+		return str
+	}
+
+	return key
+}
+
+// translatedError creates an error from a translated message
+func (c *Context) translatedError(key string, args ...interface{}) error {
+	return errors.New(c.T(key, args...))
+}
@@ -0,0 +1,139 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"strings"
+	"testing"
+)
+
+var testTranslator = MapTranslator{
+	"en-US": {
+		"greeting": "Hello %s",
+	},
+	"de": {
+		"greeting":      "Hallo %s",
+		MessageInteger:  "%s muss eine Ganzzahl sein.",
+		MessageRequired: "Das Argument %s ist erforderlich.",
+	},
+}
+
+func TestContext_T(t *testing.T) {
+	r := New().SetTranslator(testTranslator)
+
+	rt := r.On("test", nil)
+
+	ctx := &Context{route: rt}
+
+	if str := ctx.T("greeting", "world"); str != "Hello world" {
+		t.Fatal("Expected default locale translation, got:", str)
+	}
+
+	ctx.Guild = &discord.Guild{PreferredLocale: "de"}
+
+	if str := ctx.T("greeting", "Welt"); str != "Hallo Welt" {
+		t.Fatal("Expected guild locale translation, got:", str)
+	}
+
+	ctx.SetLocale("fr")
+
+	if str := ctx.T("greeting", "monde"); str != "Hello monde" {
+		t.Fatal("Expected fallback to the default locale, got:", str)
+	}
+
+	if str := ctx.T(MessageBool, "verbose"); str != "verbose must be a true/false value." {
+		t.Fatal("Expected built in message, got:", str)
+	}
+
+	if str := ctx.T("missing"); str != "missing" {
+		t.Fatal("Expected key, got:", str)
+	}
+}
+
+func TestRoute_ValidateTranslated(t *testing.T) {
+	r := New().SetTranslator(testTranslator)
+
+	rt := r.On("roll <count int> <sides int>", nil)
+
+	ctx := &Context{
+		route:         rt,
+		ArgumentCount: 2,
+		Arguments:     []string{"a", ""},
+	}
+
+	ctx.SetLocale("de")
+
+	err := rt.Validate(ctx)
+
+	if err == nil || err.Error() != "count muss eine Ganzzahl sein.\nDas Argument sides ist erforderlich." {
+		t.Fatal("Expected translated errors, got:", err)
+	}
+}
+
+func TestRoute_toCommandDataLocalized(t *testing.T) {
+	admin := testNestedRoute().Localize("de", "verwaltung", "Verwaltungsbefehle")
+
+	admin.routes["roles"].routes["add"].Localize("de", "hinzufuegen", "").Arguments["name"].Localize("de", "", "Rollenname")
+
+	data, err := admin.toCommandData()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	str := string(b)
+
+	for _, expected := range []string{
+		`"name_localizations":{"de":"verwaltung"}`,
+		`"description_localizations":{"de":"Verwaltungsbefehle"}`,
+		`"name_localizations":{"de":"hinzufuegen"}`,
+		`"description_localizations":{"de":"Rollenname"}`,
+	} {
+		if !strings.Contains(str, expected) {
+			t.Fatal("Expected", expected, "in", str)
+		}
+	}
+}
+
+func TestContextFromInteraction_Locale(t *testing.T) {
+	s, _ := newTestState(t)
+
+	r := New().SetTranslator(testTranslator)
+
+	r.On("greet", nil)
+
+	payload := `{"id":"11","application_id":"4","type":2,"channel_id":"2","token":"token","locale":"de","guild_locale":"fr",` +
+		`"user":{"id":"8","username":"test"},"data":{"id":"12","name":"greet","type":1}}`
+
+	event, err := DecodeInteraction([]byte(payload))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, err := ContextFromInteraction(s, event, r.FindCommand(event.Data.(*discord.CommandInteraction)))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if locale := ctx.Locale(); locale != "de" {
+		t.Fatal("Expected interaction locale de, got:", locale)
+	}
+
+	if str := ctx.T("greeting", "Welt"); str != "Hallo Welt" {
+		t.Fatal("Expected translation in the interaction locale, got:", str)
+	}
+
+	ctx.locale = ""
+
+	if locale := ctx.Locale(); locale != "fr" {
+		t.Fatal("Expected guild locale fr, got:", locale)
+	}
+}
@@ -2,3 +2,22 @@ package router
 
 // A middleware handler
 type MiddlewareFunc func(Handler) Handler
+
+// ErrorMiddlewareFunc is a middleware handler for error returning handlers
+type ErrorMiddlewareFunc func(HandlerFunc) HandlerFunc
+
+// wrapMiddleware converts a MiddlewareFunc into an ErrorMiddlewareFunc.
+// Errors from the next handler are passed through the middleware.
+func wrapMiddleware(f MiddlewareFunc) ErrorMiddlewareFunc {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx *Context) error {
+			var err error
+
+			f(func(ctx *Context) {
+				err = next(ctx)
+			})(ctx)
+
+			return err
+		}
+	}
+}
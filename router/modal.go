@@ -0,0 +1,217 @@
+package router
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+)
+
+var (
+	ErrNotInteraction = errors.New("context is not an interaction")
+)
+
+// TextInput is a text input field in a modal
+type TextInput struct {
+	CustomID    string
+	Label       string
+	Style       discord.TextInputStyle
+	Placeholder string
+	Value       string
+	Required    bool
+	MinLength   int
+	MaxLength   int
+}
+
+// Modal is a declarative modal definition, which can be shown in response to an interaction.
+// Submitted values are validated against the inputs, and available as arguments named by the input custom IDs.
+type Modal struct {
+	CustomID string
+	Title    string
+	Inputs   []TextInput
+}
+
+// NewModal creates a new modal with the specified custom ID (or pattern) and title
+func NewModal(customID, title string) *Modal {
+	return &Modal{
+		CustomID: customID,
+		Title:    title,
+		Inputs:   make([]TextInput, 0),
+	}
+}
+
+// Input adds text inputs to the modal
+func (m *Modal) Input(inputs ...TextInput) *Modal {
+	m.Inputs = append(m.Inputs, inputs...)
+	return m
+}
+
+// Short adds a required single line text input
+func (m *Modal) Short(customID, label string) *Modal {
+	return m.Input(TextInput{CustomID: customID, Label: label, Style: discord.TextInputShortStyle, Required: true})
+}
+
+// Paragraph adds a required multi line text input
+func (m *Modal) Paragraph(customID, label string) *Modal {
+	return m.Input(TextInput{CustomID: customID, Label: label, Style: discord.TextInputParagraphStyle, Required: true})
+}
+
+// WithCustomID returns a copy of the modal using a different custom ID.
+// This is useful to fill in the parameters of a modal registered with a pattern.
+func (m *Modal) WithCustomID(customID string) *Modal {
+	c := *m
+	c.CustomID = customID
+	return &c
+}
+
+// InteractionResponseData converts the modal to data for a modal interaction response
+func (m *Modal) InteractionResponseData() api.InteractionResponseData {
+	components := make(discord.ContainerComponents, len(m.Inputs))
+
+	for i, input := range m.Inputs {
+		c := &discord.TextInputComponent{
+			CustomID: discord.ComponentID(input.CustomID),
+			Label:    input.Label,
+			Style:    input.Style,
+			Required: input.Required,
+		}
+
+		if c.Style == 0 {
+			c.Style = discord.TextInputShortStyle
+		}
+
+		if input.Placeholder != "" {
+			c.Placeholder = option.NewNullableString(input.Placeholder)
+		}
+
+		if input.Value != "" {
+			c.Value = option.NewNullableString(input.Value)
+		}
+
+		components[i] = &discord.ActionRowComponent{c}
+	}
+
+	return api.InteractionResponseData{
+		CustomID:   option.NewNullableString(m.CustomID),
+		Title:      option.NewNullableString(m.Title),
+		Components: &components,
+	}
+}
+
+// route builds a route with an argument for each input, used to validate and access submitted values
+func (m *Modal) route() *Route {
+	r := New()
+	r.Name = m.CustomID
+	r.Usage = m.CustomID
+	r.Arguments = make(map[string]*Argument, len(m.Inputs))
+
+	for i, input := range m.Inputs {
+		r.Arguments[input.CustomID] = &Argument{
+			Index:       i,
+			position:    i,
+			Name:        input.CustomID,
+			Description: input.Label,
+			Required:    input.Required,
+			Type:        ArgumentTypeBasic,
+			MinLength:   input.MinLength,
+			MaxLength:   input.MaxLength,
+		}
+
+		if input.Required {
+			r.RequiredArgumentCount++
+		}
+	}
+
+	r.ArgumentCount = len(r.Arguments)
+
+	return r
+}
+
+// prepareModal sets the context's arguments from the submitted fields and validates them
+func (r *Route) prepareModal(ctx *ComponentContext) error {
+	args := make([]string, r.ArgumentCount)
+
+	for name, arg := range r.Arguments {
+		args[arg.Index] = ctx.Fields[name]
+	}
+
+	ctx.route = r
+	ctx.Arguments = args
+	ctx.ArgumentCount = len(args)
+
+	return r.Validate(ctx.Context)
+}
+
+// modalFields returns the submitted text input values of a modal by custom ID
+func modalFields(data *discord.ModalInteraction) map[string]string {
+	fields := make(map[string]string)
+
+	for _, container := range data.Components {
+		row, ok := container.(*discord.ActionRowComponent)
+
+		if !ok {
+			continue
+		}
+
+		for _, c := range *row {
+			if input, ok := c.(*discord.TextInputComponent); ok && input.Value != nil {
+				fields[string(input.CustomID)] = input.Value.Val
+			}
+		}
+	}
+
+	return fields
+}
+
+// OnModal adds a handler for submissions of a modal.
+// The modal's custom ID can be a pattern, the same as On.
+// Submitted values are available through the Arg accessors, named by the input custom IDs.
+func (r *ComponentRouter) OnModal(m *Modal, f ComponentHandler) *ComponentRoute {
+	rt := &ComponentRoute{
+		customIDPattern: compileCustomID(m.CustomID),
+		handler:         f,
+		middleware:      append([]MiddlewareFunc{}, r.middleware...),
+		modal:           m.route(),
+	}
+
+	r.modals = append(r.modals, rt)
+
+	return rt
+}
+
+// FindModal finds the modal route matching a custom ID, returning the parsed parameters.
+func (r *ComponentRouter) FindModal(customID string) (*ComponentRoute, map[string]string) {
+	for _, rt := range r.modals {
+		if params, ok := rt.match(customID); ok {
+			return rt, params
+		}
+	}
+
+	return nil, nil
+}
+
+// ShowModal responds to the interaction by showing a modal.
+// This must be the first response to the interaction.
+func (m *InteractionResponder) ShowModal(modal *Modal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := modal.InteractionResponseData()
+
+	return m.acknowledge(api.InteractionResponse{
+		Type: api.ModalResponse,
+		Data: &data,
+	})
+}
+
+// ShowModal shows a modal in response to an interaction.
+// Messages can't show modals, and return ErrNotInteraction.
+func (c *Context) ShowModal(modal *Modal) error {
+	responder, ok := c.responder.(*InteractionResponder)
+
+	if !ok {
+		return ErrNotInteraction
+	}
+
+	return responder.ShowModal(modal)
+}
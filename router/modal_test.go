@@ -0,0 +1,108 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"strings"
+	"testing"
+)
+
+func testModal() *Modal {
+	return NewModal("report:{userID}", "Report user").
+		Short("reason", "Reason").
+		Input(TextInput{CustomID: "details", Label: "Details", Style: discord.TextInputParagraphStyle, MaxLength: 10})
+}
+
+func TestModal_InteractionResponseData(t *testing.T) {
+	data := testModal().WithCustomID("report:5").InteractionResponseData()
+
+	if data.CustomID == nil || data.CustomID.Val != "report:5" || data.Title == nil || data.Title.Val != "Report user" {
+		t.Fatal("Expected custom ID and title to be set, got:", data)
+	}
+
+	if data.Components == nil || len(*data.Components) != 2 {
+		t.Fatal("Expected 2 action rows, got:", data.Components)
+	}
+}
+
+func TestModal_Submit(t *testing.T) {
+	var reason, details, userID string
+
+	r := NewComponentRouter()
+
+	r.OnModal(testModal(), func(ctx *ComponentContext) {
+		reason, details, userID = ctx.Arg("reason"), ctx.Arg("details"), ctx.Param("userID")
+	})
+
+	data := &discord.ModalInteraction{
+		CustomID: "report:5",
+		Components: discord.ContainerComponents{
+			&discord.ActionRowComponent{
+				&discord.TextInputComponent{CustomID: "reason", Value: option.NewNullableString("Spam")},
+			},
+			&discord.ActionRowComponent{
+				&discord.TextInputComponent{CustomID: "details", Value: option.NewNullableString("Lots")},
+			},
+		},
+	}
+
+	match, params := r.FindModal(string(data.CustomID))
+
+	if match == nil {
+		t.Fatal("Expected modal route to match")
+	}
+
+	ctx, _ := newTestInteractionContext(t, New())
+
+	match.Call(&ComponentContext{Context: ctx, CustomID: string(data.CustomID), Params: params, Fields: modalFields(data)})
+
+	if reason != "Spam" || details != "Lots" || userID != "5" {
+		t.Fatal("Expected submitted values to be available, got:", reason, details, userID)
+	}
+}
+
+func TestModal_SubmitInvalid(t *testing.T) {
+	called := false
+
+	r := NewComponentRouter()
+
+	r.OnModal(testModal(), func(ctx *ComponentContext) {
+		called = true
+	})
+
+	match, params := r.FindModal("report:5")
+
+	ctx, api := newTestInteractionContext(t, New())
+
+	match.Call(&ComponentContext{Context: ctx, Params: params, Fields: map[string]string{"details": "Far too long"}})
+
+	if called {
+		t.Fatal("Expected handler to not be called for invalid submissions")
+	}
+
+	requests := api.Requests()
+
+	if len(requests) < 1 || !strings.Contains(requests[0].Body, "reason argument is required") {
+		t.Fatal("Expected validation errors to be sent, got:", requests)
+	}
+}
+
+func TestContext_ShowModal(t *testing.T) {
+	ctx, api := newTestInteractionContext(t, New())
+
+	if err := ctx.ShowModal(testModal().WithCustomID("report:5")); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 1 || !strings.Contains(requests[0].Body, `"type":9`) {
+		t.Fatal("Expected a modal response, got:", requests)
+	}
+
+	msgCtx, _ := newTestMessageContext(t, New())
+
+	if err := msgCtx.ShowModal(testModal()); err != ErrNotInteraction {
+		t.Fatal("Expected ErrNotInteraction for messages, got:", err)
+	}
+}
@@ -0,0 +1,161 @@
+package router
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"strings"
+)
+
+var (
+	ErrGuildOnly          = errors.New("this command can only be used in a server")
+	ErrMissingPermissions = errors.New("missing permissions")
+)
+
+// MissingPermissionsError is returned when the user is missing permissions required by a route
+type MissingPermissionsError struct {
+	Missing discord.Permissions
+}
+
+// Error lists the names of the missing permissions
+func (e *MissingPermissionsError) Error() string {
+	return "missing permissions: " + strings.Join(PermissionNames(e.Missing), ", ")
+}
+
+// Is matches ErrMissingPermissions
+func (e *MissingPermissionsError) Is(target error) bool {
+	return target == ErrMissingPermissions
+}
+
+// permissionNames are the display names of each permission, in bit order
+var permissionNames = []struct {
+	permission discord.Permissions
+	name       string
+}{
+	{discord.PermissionCreateInstantInvite, "Create Invite"},
+	{discord.PermissionKickMembers, "Kick Members"},
+	{discord.PermissionBanMembers, "Ban Members"},
+	{discord.PermissionAdministrator, "Administrator"},
+	{discord.PermissionManageChannels, "Manage Channels"},
+	{discord.PermissionManageGuild, "Manage Server"},
+	{discord.PermissionAddReactions, "Add Reactions"},
+	{discord.PermissionViewAuditLog, "View Audit Log"},
+	{discord.PermissionPrioritySpeaker, "Priority Speaker"},
+	{discord.PermissionStream, "Video"},
+	{discord.PermissionViewChannel, "View Channel"},
+	{discord.PermissionSendMessages, "Send Messages"},
+	{discord.PermissionSendTTSMessages, "Send TTS Messages"},
+	{discord.PermissionManageMessages, "Manage Messages"},
+	{discord.PermissionEmbedLinks, "Embed Links"},
+	{discord.PermissionAttachFiles, "Attach Files"},
+	{discord.PermissionReadMessageHistory, "Read Message History"},
+	{discord.PermissionMentionEveryone, "Mention Everyone"},
+	{discord.PermissionUseExternalEmojis, "Use External Emojis"},
+	{discord.PermissionConnect, "Connect"},
+	{discord.PermissionSpeak, "Speak"},
+	{discord.PermissionMuteMembers, "Mute Members"},
+	{discord.PermissionDeafenMembers, "Deafen Members"},
+	{discord.PermissionMoveMembers, "Move Members"},
+	{discord.PermissionUseVAD, "Use Voice Activity"},
+	{discord.PermissionChangeNickname, "Change Nickname"},
+	{discord.PermissionManageNicknames, "Manage Nicknames"},
+	{discord.PermissionManageRoles, "Manage Roles"},
+	{discord.PermissionManageWebhooks, "Manage Webhooks"},
+	{discord.PermissionManageEmojisAndStickers, "Manage Emojis and Stickers"},
+	{discord.PermissionUseSlashCommands, "Use Application Commands"},
+	{discord.PermissionRequestToSpeak, "Request to Speak"},
+	{discord.PermissionManageThreads, "Manage Threads"},
+	{discord.PermissionCreatePublicThreads, "Create Public Threads"},
+	{discord.PermissionCreatePrivateThreads, "Create Private Threads"},
+	{discord.PermissionUseExternalStickers, "Use External Stickers"},
+	{discord.PermissionSendMessagesInThreads, "Send Messages in Threads"},
+	{discord.PermissionStartEmbeddedActivities, "Start Activities"},
+	{discord.PermissionModerateMembers, "Timeout Members"},
+}
+
+// PermissionNames returns the display names of the permissions in a set, such as "Manage Messages"
+func PermissionNames(p discord.Permissions) []string {
+	names := make([]string, 0)
+
+	for _, perm := range permissionNames {
+		if p&perm.permission != 0 {
+			names = append(names, perm.name)
+		}
+	}
+
+	return names
+}
+
+// RequirePermissions requires the user to have permissions in the channel for this route and all sub-routes added afterwards.
+// The permissions are also exported as the command's default member permissions, which hides the command from users without them.
+// As permissions only exist in guilds, this disables the command in direct messages.
+func (r *Route) RequirePermissions(permissions discord.Permissions) *Route {
+	p := permissions
+
+	if r.DefaultMemberPermissions != nil {
+		p |= *r.DefaultMemberPermissions
+	}
+
+	r.DefaultMemberPermissions = &p
+
+	return r.AllowDM(false).Check(func(ctx *Context) error {
+		if ctx.Guild == nil {
+			return ErrGuildOnly
+		}
+
+		current, err := ctx.Permissions()
+
+		if err != nil {
+			return err
+		}
+
+		if missing := permissions &^ current; missing != 0 {
+			return &MissingPermissionsError{Missing: missing}
+		}
+
+		return nil
+	})
+}
+
+// AllowDM sets whether the command can be used in direct messages, exported as the command's DM permission.
+// This only applies to global commands.
+func (r *Route) AllowDM(allow bool) *Route {
+	r.DMPermission = &allow
+	return r
+}
+
+// Member returns the guild member who sent the message or interaction
+func (c *Context) Member() (*discord.Member, error) {
+	if c.Guild == nil {
+		return nil, ErrGuildOnly
+	}
+
+	if c.Interaction != nil && c.Interaction.Member != nil {
+		return c.Interaction.Member, nil
+	}
+
+	if c.Event != nil && c.Event.Member != nil {
+		// Message members don't include the user
+		m := *c.Event.Member
+		m.User = c.User
+		return &m, nil
+	}
+
+	return c.Session.Member(c.Guild.ID, c.User.ID)
+}
+
+// Permissions calculates the user's permissions in the current channel, including overwrites
+func (c *Context) Permissions() (discord.Permissions, error) {
+	member, err := c.Member()
+
+	if err != nil {
+		return 0, err
+	}
+
+	channel := discord.Channel{}
+
+	if c.Channel != nil {
+		channel = *c.Channel
+	}
+
+	return discord.CalcOverwrites(*c.Guild, channel, *member), nil
+}
@@ -0,0 +1,103 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"strings"
+	"testing"
+)
+
+func TestRoute_RequirePermissions(t *testing.T) {
+	var handled error
+
+	r := New().SetErrorHandler(func(ctx *Context, err error) {
+		handled = err
+	})
+
+	called := false
+
+	rt := r.On("purge <count int>", func(ctx *Context) {
+		called = true
+	}).RequirePermissions(discord.PermissionManageMessages | discord.PermissionReadMessageHistory)
+
+	ctx, _ := newTestMessageContext(t, rt)
+	ctx.Arguments = []string{"10"}
+	ctx.ArgumentCount = 1
+	ctx.Guild.Roles = []discord.Role{
+		{ID: 5, Permissions: discord.PermissionReadMessageHistory},
+		{ID: 10, Permissions: discord.PermissionManageMessages},
+	}
+	ctx.Event = &gateway.MessageCreateEvent{Member: &discord.Member{}}
+
+	err := rt.Call(ctx)
+
+	var permErr *MissingPermissionsError
+
+	if !errors.As(err, &permErr) || permErr.Missing != discord.PermissionManageMessages || handled != err || called {
+		t.Fatal("Expected missing Manage Messages, got:", err, handled)
+	}
+
+	if err.Error() != "missing permissions: Manage Messages" {
+		t.Fatal("Unexpected error message:", err)
+	}
+
+	ctx.Event.Member.RoleIDs = []discord.RoleID{10}
+
+	if err := rt.Call(ctx); err != nil || !called {
+		t.Fatal("Expected the route to run, got:", err)
+	}
+
+	ctx.Guild = nil
+
+	if err := rt.Call(ctx); err != ErrGuildOnly {
+		t.Fatal("Expected guild only error, got:", err)
+	}
+}
+
+func TestRoute_toCommandDataPermissions(t *testing.T) {
+	r := New()
+
+	r.Group(func(g *Route) {
+		g.RequirePermissions(discord.PermissionBanMembers)
+
+		g.On("ban <@user>", nil).Desc("Bans a user").Argument("user", func(arg *Argument) {
+			arg.Description = "User to ban"
+		})
+	})
+
+	r.On("ping", nil).Desc("Pings").AllowDM(true)
+
+	data, err := r.routes["ban"].toCommandData()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(data)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if str := string(b); !strings.Contains(str, `"default_member_permissions":"4"`) || !strings.Contains(str, `"dm_permission":false`) {
+		t.Fatal("Expected permissions to be exported, got:", str)
+	}
+
+	data, err = r.routes["ping"].toCommandData()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw := `{"id":"10","type":1,"name":"ping","description":"Pings","default_permission":true,"dm_permission":true}`
+
+	if equal, err := commandEqual(data, json.RawMessage(raw)); err != nil || !equal {
+		t.Fatal("Expected registered command to equal its data, got:", equal, err)
+	}
+
+	if equal, _ := commandEqual(data, json.RawMessage(strings.Replace(raw, "true", "false", 1))); equal {
+		t.Fatal("Expected changed DM permission to be detected")
+	}
+}
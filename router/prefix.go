@@ -0,0 +1,103 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"strings"
+	"sync"
+)
+
+// PrefixResolver resolves the command prefixes which are valid for a message.
+type PrefixResolver interface {
+	Prefixes(evt *gateway.MessageCreateEvent) []string
+}
+
+// PrefixResolverFunc is a func implementing PrefixResolver
+type PrefixResolverFunc func(evt *gateway.MessageCreateEvent) []string
+
+// Prefixes calls the underlying func
+func (f PrefixResolverFunc) Prefixes(evt *gateway.MessageCreateEvent) []string {
+	return f(evt)
+}
+
+// StaticPrefixes is a PrefixResolver which always returns the same prefixes
+type StaticPrefixes []string
+
+// Prefixes returns the static prefixes
+func (p StaticPrefixes) Prefixes(evt *gateway.MessageCreateEvent) []string {
+	return p
+}
+
+// GuildPrefixes is a PrefixResolver holding configurable prefixes per guild.
+// Guilds without any prefixes set, as well as DMs, use the default prefixes.
+type GuildPrefixes struct {
+	mu       sync.RWMutex
+	prefixes map[discord.GuildID][]string
+	defaults []string
+}
+
+// NewGuildPrefixes creates a new GuildPrefixes resolver with the specified default prefixes
+func NewGuildPrefixes(defaults ...string) *GuildPrefixes {
+	return &GuildPrefixes{
+		prefixes: make(map[discord.GuildID][]string),
+		defaults: defaults,
+	}
+}
+
+// Set sets the prefixes for a guild. Setting no prefixes resets the guild to the defaults.
+func (g *GuildPrefixes) Set(guildID discord.GuildID, prefixes ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if len(prefixes) == 0 {
+		delete(g.prefixes, guildID)
+		return
+	}
+
+	g.prefixes[guildID] = prefixes
+}
+
+// Get returns the prefixes for a guild
+func (g *GuildPrefixes) Get(guildID discord.GuildID) []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if prefixes, ok := g.prefixes[guildID]; ok {
+		return prefixes
+	}
+
+	return g.defaults
+}
+
+// Prefixes returns the prefixes for the message's guild
+func (g *GuildPrefixes) Prefixes(evt *gateway.MessageCreateEvent) []string {
+	return g.Get(evt.GuildID)
+}
+
+// mentionPrefixes returns both forms of a user mention, used to allow "@Bot command"
+func mentionPrefixes(id discord.UserID) []string {
+	return []string{"<@" + id.String() + ">", "<@!" + id.String() + ">"}
+}
+
+// matchPrefix finds the longest prefix the content starts with and returns it, as well as the content without it.
+func matchPrefix(content string, prefixes []string) (string, string, bool) {
+	var matched string
+	found := false
+
+	for _, prefix := range prefixes {
+		if prefix == "" || len(prefix) <= len(matched) {
+			continue
+		}
+
+		if strings.HasPrefix(content, prefix) {
+			matched = prefix
+			found = true
+		}
+	}
+
+	if !found {
+		return "", content, false
+	}
+
+	return matched, content[len(matched):], true
+}
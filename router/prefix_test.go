@@ -0,0 +1,65 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"testing"
+)
+
+func TestMatchPrefix(t *testing.T) {
+	prefix, str, ok := matchPrefix("!!ping", []string{"!", "!!", "?"})
+
+	if !ok {
+		t.Fatal("Expected a prefix to match")
+	}
+
+	if prefix != "!!" {
+		t.Fatal("Expected longest prefix !! to match, got:", prefix)
+	}
+
+	if str != "ping" {
+		t.Fatal("Expected remaining content to be ping, got:", str)
+	}
+
+	if _, _, ok := matchPrefix("ping", []string{"!", ""}); ok {
+		t.Fatal("Expected no prefix to match")
+	}
+}
+
+func TestMatchPrefix_Mention(t *testing.T) {
+	prefix, str, ok := matchPrefix("<@!1234> ping", mentionPrefixes(1234))
+
+	if !ok || prefix != "<@!1234>" {
+		t.Fatal("Expected mention prefix to match, got:", prefix)
+	}
+
+	if str != " ping" {
+		t.Fatal("Expected remaining content to be ping, got:", str)
+	}
+}
+
+func TestGuildPrefixes(t *testing.T) {
+	p := NewGuildPrefixes("!")
+
+	p.Set(1, "?", "$")
+
+	evt := &gateway.MessageCreateEvent{Message: discord.Message{GuildID: 1}}
+
+	if prefixes := p.Prefixes(evt); len(prefixes) != 2 || prefixes[0] != "?" {
+		t.Fatal("Expected guild prefixes, got:", prefixes)
+	}
+
+	evt.GuildID = 2
+
+	if prefixes := p.Prefixes(evt); len(prefixes) != 1 || prefixes[0] != "!" {
+		t.Fatal("Expected default prefixes, got:", prefixes)
+	}
+
+	p.Set(1)
+
+	evt.GuildID = 1
+
+	if prefixes := p.Prefixes(evt); len(prefixes) != 1 || prefixes[0] != "!" {
+		t.Fatal("Expected default prefixes after reset, got:", prefixes)
+	}
+}
@@ -0,0 +1,143 @@
+package router
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/utils/json/option"
+	"github.com/diamondburned/arikawa/v3/utils/sendpart"
+	"io"
+)
+
+var (
+	ErrComponentsOnly = errors.New("components need content, embeds or files to be sent")
+)
+
+// ReplyBuilder composes a reply containing any combination of content, embeds, files and components.
+// It is translated to api.SendMessageData for messages and api.InteractionResponseData for interactions.
+type ReplyBuilder struct {
+	Content         string
+	Embeds          []discord.Embed
+	Files           []sendpart.File
+	Components      discord.ContainerComponents
+	AllowedMentions *api.AllowedMentions
+	Reference       *discord.MessageReference
+	TTS             bool
+	Ephemeral       bool
+}
+
+// NewReply creates a new, empty reply builder
+func NewReply() *ReplyBuilder {
+	return &ReplyBuilder{}
+}
+
+// Text sets the reply's content
+func (b *ReplyBuilder) Text(content string) *ReplyBuilder {
+	b.Content = content
+	return b
+}
+
+// Embed adds embeds to the reply
+func (b *ReplyBuilder) Embed(embeds ...discord.Embed) *ReplyBuilder {
+	b.Embeds = append(b.Embeds, embeds...)
+	return b
+}
+
+// File adds a file by name and the data from r
+func (b *ReplyBuilder) File(name string, r io.Reader) *ReplyBuilder {
+	b.Files = append(b.Files, sendpart.File{Name: name, Reader: r})
+	return b
+}
+
+// Component adds container components, such as action rows, to the reply.
+// Discord doesn't send messages with only components, so content, embeds or files are required.
+func (b *ReplyBuilder) Component(components ...discord.ContainerComponent) *ReplyBuilder {
+	b.Components = append(b.Components, components...)
+	return b
+}
+
+// AllowMentions sets the mentions which are allowed to ping
+func (b *ReplyBuilder) AllowMentions(mentions api.AllowedMentions) *ReplyBuilder {
+	b.AllowedMentions = &mentions
+	return b
+}
+
+// ReplyTo sets the message the reply references.
+// Message replies reference the invoking message by default.
+func (b *ReplyBuilder) ReplyTo(id discord.MessageID) *ReplyBuilder {
+	b.Reference = &discord.MessageReference{MessageID: id}
+	return b
+}
+
+// SetTTS sets whether the reply is a text-to-speech message
+func (b *ReplyBuilder) SetTTS(tts bool) *ReplyBuilder {
+	b.TTS = tts
+	return b
+}
+
+// SetEphemeral sets whether the reply should only be visible to the user
+func (b *ReplyBuilder) SetEphemeral(ephemeral bool) *ReplyBuilder {
+	b.Ephemeral = ephemeral
+	return b
+}
+
+// Empty returns true if the reply has no content, embeds or files
+func (b *ReplyBuilder) Empty() bool {
+	return b.Content == "" && len(b.Embeds) == 0 && len(b.Files) == 0
+}
+
+// Validate checks the reply can be sent, which needs content, embeds or files.
+// Replies with only components return ErrComponentsOnly, and other empty replies ErrEmptyText.
+func (b *ReplyBuilder) Validate() error {
+	if !b.Empty() {
+		return nil
+	}
+
+	if len(b.Components) > 0 {
+		return ErrComponentsOnly
+	}
+
+	return ErrEmptyText
+}
+
+// SendMessageData converts the reply to data for sending a message
+func (b *ReplyBuilder) SendMessageData() api.SendMessageData {
+	return api.SendMessageData{
+		Content:         b.Content,
+		TTS:             b.TTS,
+		Embeds:          b.Embeds,
+		Files:           b.Files,
+		Components:      b.Components,
+		AllowedMentions: b.AllowedMentions,
+		Reference:       b.Reference,
+	}
+}
+
+// InteractionResponseData converts the reply to data for an interaction response or follow-up
+func (b *ReplyBuilder) InteractionResponseData() api.InteractionResponseData {
+	data := api.InteractionResponseData{
+		TTS:             b.TTS,
+		Files:           b.Files,
+		AllowedMentions: b.AllowedMentions,
+	}
+
+	if b.Content != "" {
+		data.Content = option.NewNullableString(b.Content)
+	}
+
+	if len(b.Embeds) > 0 {
+		embeds := b.Embeds
+		data.Embeds = &embeds
+	}
+
+	if len(b.Components) > 0 {
+		components := b.Components
+		data.Components = &components
+	}
+
+	if b.Ephemeral {
+		data.Flags = api.EphemeralResponse
+	}
+
+	return data
+}
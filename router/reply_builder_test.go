@@ -0,0 +1,65 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/api"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"strings"
+	"testing"
+)
+
+func testReply() *ReplyBuilder {
+	return NewReply().
+		Text("Hello").
+		Embed(discord.Embed{Title: "First"}, discord.Embed{Title: "Second"}).
+		File("test.txt", strings.NewReader("test")).
+		Component(&discord.ActionRowComponent{
+			&discord.ButtonComponent{Label: "Click", CustomID: "click", Style: discord.PrimaryButtonStyle()},
+		}).
+		AllowMentions(api.AllowedMentions{Parse: []api.AllowedMentionType{}})
+}
+
+func TestReplyBuilder_SendMessageData(t *testing.T) {
+	data := testReply().ReplyTo(5).SendMessageData()
+
+	if data.Content != "Hello" || len(data.Embeds) != 2 || len(data.Files) != 1 || len(data.Components) != 1 {
+		t.Fatal("Expected content, 2 embeds, 1 file and 1 component, got:", data)
+	}
+
+	if data.AllowedMentions == nil || data.Reference == nil || data.Reference.MessageID != 5 {
+		t.Fatal("Expected allowed mentions and a reference to message 5, got:", data)
+	}
+}
+
+func TestReplyBuilder_InteractionResponseData(t *testing.T) {
+	data := testReply().SetEphemeral(true).InteractionResponseData()
+
+	if data.Content == nil || data.Content.Val != "Hello" {
+		t.Fatal("Expected content to be Hello, got:", data.Content)
+	}
+
+	if data.Embeds == nil || len(*data.Embeds) != 2 || data.Components == nil || len(*data.Components) != 1 {
+		t.Fatal("Expected 2 embeds and 1 component, got:", data)
+	}
+
+	if data.Flags != api.EphemeralResponse {
+		t.Fatal("Expected ephemeral flag, got:", data.Flags)
+	}
+}
+
+func TestReplyBuilder_Reply(t *testing.T) {
+	ctx, api := newTestMessageContext(t, New().On("test", nil))
+
+	if _, err := ctx.ReplyComplex(NewReply()); err != ErrEmptyText {
+		t.Fatal("Expected ErrEmptyText for an empty reply, got:", err)
+	}
+
+	if _, err := ctx.ReplyComplex(NewReply().Text("Hello").Embed(discord.Embed{Title: "Test"})); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 1 || !strings.Contains(requests[0].Body, `"message_reference":{"message_id":"7"}`) {
+		t.Fatal("Expected a reply to the invoking message, got:", requests)
+	}
+}
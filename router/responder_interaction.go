@@ -8,10 +8,119 @@ import (
 	"github.com/diamondburned/arikawa/v3/utils/sendpart"
 	"io"
 	"strings"
+	"sync"
 )
 
+// InteractionResponder responds to interactions.
+// The first response acknowledges the interaction, further responses are sent as follow-up messages.
 type InteractionResponder struct {
-	ctx *Context
+	ctx          *Context
+	mu           sync.Mutex
+	acknowledged bool
+}
+
+// Acknowledged returns true if the interaction has been responded to or deferred
+func (m *InteractionResponder) Acknowledged() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.acknowledged
+}
+
+// Defer acknowledges the interaction without a message, showing a loading state to the user.
+// Replies sent afterwards are sent as follow-up messages.
+func (m *InteractionResponder) Defer(ephemeral bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.acknowledged {
+		return nil
+	}
+
+	resp := api.InteractionResponse{
+		Type: api.DeferredMessageInteractionWithSource,
+	}
+
+	if ephemeral {
+		resp.Data = &api.InteractionResponseData{Flags: api.EphemeralResponse}
+	}
+
+	return m.acknowledge(resp)
+}
+
+// UpdateMessage updates the message a component is attached to.
+// If the interaction was already acknowledged, the original response is edited instead.
+func (m *InteractionResponder) UpdateMessage(b *ReplyBuilder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data := b.InteractionResponseData()
+
+	if m.acknowledged {
+		_, err := m.ctx.Session.EditInteractionResponse(m.ctx.Interaction.AppID, m.ctx.Interaction.Token, api.EditInteractionResponseData{
+			Content:         data.Content,
+			Embeds:          data.Embeds,
+			Components:      data.Components,
+			AllowedMentions: data.AllowedMentions,
+			Files:           data.Files,
+		})
+
+		return err
+	}
+
+	return m.acknowledge(api.InteractionResponse{
+		Type: api.UpdateMessage,
+		Data: &data,
+	})
+}
+
+// DeferredMessageUpdate acknowledges a component interaction without updating the message yet.
+func (m *InteractionResponder) DeferredMessageUpdate() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.acknowledged {
+		return nil
+	}
+
+	return m.acknowledge(api.InteractionResponse{
+		Type: api.DeferredMessageUpdate,
+	})
+}
+
+// acknowledge sends the initial interaction response. The caller must hold the lock.
+func (m *InteractionResponder) acknowledge(resp api.InteractionResponse) error {
+	if err := m.ctx.Session.RespondInteraction(m.ctx.Interaction.ID, m.ctx.Interaction.Token, resp); err != nil {
+		return err
+	}
+
+	m.acknowledged = true
+
+	return nil
+}
+
+// respond sends the initial interaction response, or a follow-up message if the interaction
+// was already acknowledged, and returns the created message.
+func (m *InteractionResponder) respond(data api.InteractionResponseData) (*discord.Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	interaction := m.ctx.Interaction
+
+	if m.acknowledged {
+		return m.ctx.Session.CreateInteractionFollowup(interaction.AppID, interaction.Token, data)
+	}
+
+	err := m.acknowledge(api.InteractionResponse{
+		Type: api.MessageInteractionWithSource,
+		Data: &data,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ctx.Session.InteractionResponse(interaction.AppID, interaction.Token)
 }
 
 // Usage builds and shows command usage
@@ -64,42 +173,68 @@ func (m *InteractionResponder) ReplyTo(to discord.UserID, text string) (*discord
 	return m.Reply(fmt.Sprintf("%s %s", to.Mention(), text))
 }
 
+// EditResponse edits the original interaction response
+func (m *InteractionResponder) EditResponse(text string) (*discord.Message, error) {
+	return m.ctx.Session.EditInteractionResponse(m.ctx.Interaction.AppID, m.ctx.Interaction.Token, api.EditInteractionResponseData{
+		Content: option.NewNullableString(text),
+	})
+}
+
+// DeleteResponse deletes the original interaction response
+func (m *InteractionResponder) DeleteResponse() error {
+	return m.ctx.Session.DeleteInteractionResponse(m.ctx.Interaction.AppID, m.ctx.Interaction.Token)
+}
+
+// EditFollowup edits a follow-up message sent for the interaction
+func (m *InteractionResponder) EditFollowup(id discord.MessageID, text string) (*discord.Message, error) {
+	return m.ctx.Session.EditInteractionFollowup(m.ctx.Interaction.AppID, id, m.ctx.Interaction.Token, api.EditInteractionResponseData{
+		Content: option.NewNullableString(text),
+	})
+}
+
 // Reply with a user mention
 func (m *InteractionResponder) Reply(text string) (*discord.Message, error) {
 	if text == "" {
 		return nil, ErrEmptyText
 	}
 
-	err := m.ctx.Session.RespondInteraction(m.ctx.Interaction.ID, m.ctx.Interaction.Token, api.InteractionResponse{
-		Type: api.MessageInteractionWithSource,
-		Data: &api.InteractionResponseData{Content: option.NewNullableString(text)},
+	return m.respond(api.InteractionResponseData{Content: option.NewNullableString(text)})
+}
+
+// ReplyEphemeral replies with a message only the user can see
+func (m *InteractionResponder) ReplyEphemeral(text string) (*discord.Message, error) {
+	if text == "" {
+		return nil, ErrEmptyText
+	}
+
+	return m.respond(api.InteractionResponseData{
+		Content: option.NewNullableString(text),
+		Flags:   api.EphemeralResponse,
 	})
+}
 
-	return nil, err
+// ReplyComplex replies with a composed message.
+// Message references aren't supported by interactions and are ignored.
+func (m *InteractionResponder) ReplyComplex(b *ReplyBuilder) (*discord.Message, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	return m.respond(b.InteractionResponseData())
 }
 
 // ReplyEmbed replies to a user with an embed object
 func (m *InteractionResponder) ReplyEmbed(embed *discord.Embed) (*discord.Message, error) {
-	err := m.ctx.Session.RespondInteraction(m.ctx.Interaction.ID, m.ctx.Interaction.Token, api.InteractionResponse{
-		Type: api.MessageInteractionWithSource,
-		Data: &api.InteractionResponseData{
-			Embeds: &[]discord.Embed{*embed},
-		},
+	return m.respond(api.InteractionResponseData{
+		Embeds: &[]discord.Embed{*embed},
 	})
-
-	return nil, err
 }
 
 // ReplyFile replies to a user with a file object
 func (m *InteractionResponder) ReplyFile(name string, r io.Reader) (*discord.Message, error) {
-	err := m.ctx.Session.RespondInteraction(m.ctx.Interaction.ID, m.ctx.Interaction.Token, api.InteractionResponse{
-		Type: api.MessageInteractionWithSource,
-		Data: &api.InteractionResponseData{
-			Files: []sendpart.File{
-				{Name: name, Reader: r},
-			},
+	return m.respond(api.InteractionResponseData{
+		Files: []sendpart.File{
+			{Name: name, Reader: r},
 		},
 	})
-
-	return nil, err
 }
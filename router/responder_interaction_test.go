@@ -0,0 +1,141 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"strings"
+	"testing"
+)
+
+func TestInteractionResponder_Reply(t *testing.T) {
+	ctx, api := newTestInteractionContext(t, New().On("test", nil))
+
+	msg, err := ctx.Reply("first")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg == nil || msg.ID != 1 {
+		t.Fatal("Expected reply to return the original response, got:", msg)
+	}
+
+	if _, err := ctx.Reply("second"); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 3 {
+		t.Fatal("Expected 3 requests, got:", requests)
+	}
+
+	if requests[0].Path != "/api/v9/interactions/3/token/callback" || !strings.Contains(requests[0].Body, `"type":4`) {
+		t.Fatal("Expected first reply to respond to the interaction, got:", requests[0])
+	}
+
+	if requests[1].Method != "GET" || !strings.HasSuffix(requests[1].Path, "/webhooks/4/token/messages/@original") {
+		t.Fatal("Expected original response to be fetched, got:", requests[1])
+	}
+
+	if requests[2].Method != "POST" || !strings.HasSuffix(requests[2].Path, "/webhooks/4/token") {
+		t.Fatal("Expected second reply to be a follow-up, got:", requests[2])
+	}
+}
+
+func TestInteractionResponder_Defer(t *testing.T) {
+	ctx, api := newTestInteractionContext(t, New().On("test", nil))
+
+	if err := ctx.Defer(true); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ctx.Reply("done"); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 2 {
+		t.Fatal("Expected 2 requests, got:", requests)
+	}
+
+	if !strings.Contains(requests[0].Body, `"type":5`) || !strings.Contains(requests[0].Body, `"flags":64`) {
+		t.Fatal("Expected an ephemeral deferred response, got:", requests[0])
+	}
+
+	if requests[1].Method != "POST" || !strings.HasSuffix(requests[1].Path, "/webhooks/4/token") {
+		t.Fatal("Expected reply to be a follow-up, got:", requests[1])
+	}
+}
+
+func TestInteractionResponder_ReplyComponentsOnly(t *testing.T) {
+	ctx, api := newTestInteractionContext(t, New().On("test", nil))
+
+	if err := ctx.Defer(false); err != nil {
+		t.Fatal(err)
+	}
+
+	b := NewReply().Component(&discord.ActionRowComponent{
+		&discord.ButtonComponent{Label: "Click", CustomID: "click", Style: discord.PrimaryButtonStyle()},
+	})
+
+	if _, err := ctx.ReplyComplex(b); err != ErrComponentsOnly {
+		t.Fatal("Expected ErrComponentsOnly, got:", err)
+	}
+
+	if requests := api.Requests(); len(requests) != 1 {
+		t.Fatal("Expected only the deferred response, got:", requests)
+	}
+
+	if _, err := ctx.ReplyComplex(b.Text("Choose")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInteractionResponder_ReplyEphemeral(t *testing.T) {
+	ctx, api := newTestInteractionContext(t, New().On("test", nil))
+
+	if _, err := ctx.ReplyEphemeral("secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) < 1 || !strings.Contains(requests[0].Body, `"flags":64`) {
+		t.Fatal("Expected an ephemeral response, got:", requests)
+	}
+}
+
+func TestInteractionResponder_EditResponse(t *testing.T) {
+	ctx, api := newTestInteractionContext(t, New().On("test", nil))
+
+	if _, err := ctx.EditResponse("Done!"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ctx.EditFollowup(9, "Edited"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ctx.DeleteResponse(); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 3 {
+		t.Fatal("Expected 3 requests, got:", requests)
+	}
+
+	if requests[0].Method != "PATCH" || !strings.HasSuffix(requests[0].Path, "/webhooks/4/token/messages/@original") {
+		t.Fatal("Expected the original response to be edited, got:", requests[0])
+	}
+
+	if requests[1].Method != "PATCH" || !strings.HasSuffix(requests[1].Path, "/webhooks/4/token/messages/9") {
+		t.Fatal("Expected the follow-up to be edited, got:", requests[1])
+	}
+
+	if requests[2].Method != "DELETE" || !strings.HasSuffix(requests[2].Path, "/webhooks/4/token/messages/@original") {
+		t.Fatal("Expected the original response to be deleted, got:", requests[2])
+	}
+}
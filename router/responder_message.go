@@ -8,14 +8,39 @@ import (
 	"github.com/diamondburned/arikawa/v3/utils/sendpart"
 	"io"
 	"strings"
+	"sync"
+	"time"
 )
 
+// MessageResponder responds to messages.
+// The first message sent is tracked as the response, which can be edited or deleted.
 type MessageResponder struct {
-	ctx *Context
+	// EphemeralFallback is how ephemeral replies are sent
+	EphemeralFallback EphemeralFallbackType
+	// EphemeralDeleteAfter is how long ephemeral replies are kept before deletion, DefaultEphemeralDeleteAfter if unset
+	EphemeralDeleteAfter time.Duration
+
+	ctx      *Context
+	mu       sync.Mutex
+	response *discord.Message
 }
 
+// EphemeralFallbackType is how ephemeral replies are sent for messages, which don't support them
+type EphemeralFallbackType int
+
+const (
+	// EphemeralDelete sends a normal reply which is deleted after a delay
+	EphemeralDelete EphemeralFallbackType = iota
+	// EphemeralDM sends the reply as a direct message, falling back to EphemeralDelete if DMs are closed
+	EphemeralDM
+)
+
+// DefaultEphemeralDeleteAfter is how long ephemeral replies to messages are kept before deletion by default
+const DefaultEphemeralDeleteAfter = 15 * time.Second
+
 var (
-	ErrEmptyText = errors.New("text is empty")
+	ErrEmptyText  = errors.New("text is empty")
+	ErrNoResponse = errors.New("no response has been sent")
 )
 
 // Usage builds and shows command usage
@@ -39,7 +64,7 @@ func (m *MessageResponder) Send(text string) (*discord.Message, error) {
 		return nil, err
 	}
 
-	return m.ctx.Session.SendMessage(m.ctx.Channel.ID, text)
+	return m.track(m.ctx.Session.SendMessage(m.ctx.Channel.ID, text))
 }
 
 // Sendf Sends formattable text to the originating channel
@@ -55,7 +80,7 @@ func (m *MessageResponder) SendFile(name string, r io.Reader) (*discord.Message,
 		},
 	}
 
-	return m.ctx.Session.SendMessageComplex(m.ctx.Channel.ID, data)
+	return m.track(m.ctx.Session.SendMessageComplex(m.ctx.Channel.ID, data))
 }
 
 // Replyf Builds a message and replies with formatted text
@@ -68,6 +93,54 @@ func (m *MessageResponder) ReplyTo(to discord.UserID, text string) (*discord.Mes
 	return m.Send(fmt.Sprintf("%s %s", to.Mention(), text))
 }
 
+// track stores the first sent message as the response
+func (m *MessageResponder) track(msg *discord.Message, err error) (*discord.Message, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.response == nil {
+		m.response = msg
+	}
+
+	return msg, nil
+}
+
+// EditResponse edits the first message sent in response
+func (m *MessageResponder) EditResponse(text string) (*discord.Message, error) {
+	m.mu.Lock()
+	response := m.response
+	m.mu.Unlock()
+
+	if response == nil {
+		return nil, ErrNoResponse
+	}
+
+	return m.ctx.Session.EditText(response.ChannelID, response.ID, text)
+}
+
+// DeleteResponse deletes the first message sent in response
+func (m *MessageResponder) DeleteResponse() error {
+	m.mu.Lock()
+	response := m.response
+	m.response = nil
+	m.mu.Unlock()
+
+	if response == nil {
+		return ErrNoResponse
+	}
+
+	return m.ctx.Session.DeleteMessage(response.ChannelID, response.ID, "")
+}
+
+// EditFollowup edits a message sent in response to the originating channel
+func (m *MessageResponder) EditFollowup(id discord.MessageID, text string) (*discord.Message, error) {
+	return m.ctx.Session.EditText(m.ctx.Channel.ID, id, text)
+}
+
 func checkMessageChannel(ctx *Context) error {
 	if ctx.Channel.Type == discord.DirectMessage {
 		var err error
@@ -82,6 +155,16 @@ func checkMessageChannel(ctx *Context) error {
 	return nil
 }
 
+// Defer shows a typing indicator in the originating channel.
+// Messages can't be ephemeral, so ephemeral is ignored.
+func (m *MessageResponder) Defer(ephemeral bool) error {
+	if err := checkMessageChannel(m.ctx); err != nil {
+		return err
+	}
+
+	return m.ctx.Session.Typing(m.ctx.Channel.ID)
+}
+
 // Reply to a message
 func (m *MessageResponder) Reply(text string) (*discord.Message, error) {
 	if text == "" {
@@ -92,7 +175,88 @@ func (m *MessageResponder) Reply(text string) (*discord.Message, error) {
 		return nil, err
 	}
 
-	return m.ctx.Session.SendTextReply(m.ctx.Channel.ID, text, m.ctx.Message.ID)
+	return m.track(m.ctx.Session.SendTextReply(m.ctx.Channel.ID, text, m.ctx.Message.ID))
+}
+
+// ReplyEphemeral replies with a message only the user should see.
+// As messages can't be ephemeral, this either sends a direct message or a reply which is deleted later.
+func (m *MessageResponder) ReplyEphemeral(text string) (*discord.Message, error) {
+	if text == "" {
+		return nil, ErrEmptyText
+	}
+
+	return m.sendEphemeral(api.SendMessageData{
+		Content:   text,
+		Reference: &discord.MessageReference{MessageID: m.ctx.Message.ID},
+	})
+}
+
+// ReplyComplex replies with a composed message, referencing the invoking message by default
+func (m *MessageResponder) ReplyComplex(b *ReplyBuilder) (*discord.Message, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	data := b.SendMessageData()
+
+	if data.Reference == nil {
+		data.Reference = &discord.MessageReference{MessageID: m.ctx.Message.ID}
+	}
+
+	if b.Ephemeral {
+		return m.sendEphemeral(data)
+	}
+
+	if err := checkMessageChannel(m.ctx); err != nil {
+		return nil, err
+	}
+
+	return m.track(m.ctx.Session.SendMessageComplex(m.ctx.Channel.ID, data))
+}
+
+// sendEphemeral sends a message using the EphemeralFallback.
+// Errors deleting the reply are passed to the route's ErrorHandler.
+func (m *MessageResponder) sendEphemeral(data api.SendMessageData) (*discord.Message, error) {
+	if m.EphemeralFallback == EphemeralDM && m.ctx.Channel.Type != discord.DirectMessage {
+		c, err := m.ctx.Session.CreatePrivateChannel(m.ctx.User.ID)
+
+		if err == nil {
+			dm := data
+			dm.Reference = nil
+
+			msg, err := m.ctx.Session.SendMessageComplex(c.ID, dm)
+
+			if err == nil {
+				return m.track(msg, nil)
+			}
+		}
+	}
+
+	if err := checkMessageChannel(m.ctx); err != nil {
+		return nil, err
+	}
+
+	msg, err := m.track(m.ctx.Session.SendMessageComplex(m.ctx.Channel.ID, data))
+
+	if err != nil {
+		return nil, err
+	}
+
+	deleteAfter := m.EphemeralDeleteAfter
+
+	if deleteAfter == 0 {
+		deleteAfter = DefaultEphemeralDeleteAfter
+	}
+
+	time.AfterFunc(deleteAfter, func() {
+		if err := m.ctx.Session.DeleteMessage(msg.ChannelID, msg.ID, ""); err != nil && m.ctx.route != nil {
+			if errorHandler := m.ctx.route.ErrorHandler(); errorHandler != nil {
+				errorHandler(m.ctx, err)
+			}
+		}
+	})
+
+	return msg, nil
 }
 
 // ReplyEmbed replies to a user with an embed object
@@ -101,7 +265,7 @@ func (m *MessageResponder) ReplyEmbed(embed *discord.Embed) (*discord.Message, e
 		return nil, err
 	}
 
-	return m.ctx.Session.SendEmbedReply(m.ctx.Channel.ID, m.ctx.Message.ID, *embed)
+	return m.track(m.ctx.Session.SendEmbedReply(m.ctx.Channel.ID, m.ctx.Message.ID, *embed))
 }
 
 // ReplyFile replies to a user with a file object
@@ -118,5 +282,5 @@ func (m *MessageResponder) ReplyFile(name string, r io.Reader) (*discord.Message
 		return nil, err
 	}
 
-	return m.ctx.Session.SendMessageComplex(m.ctx.Channel.ID, data)
+	return m.track(m.ctx.Session.SendMessageComplex(m.ctx.Channel.ID, data))
 }
@@ -0,0 +1,115 @@
+package router
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMessageResponder_ReplyEphemeralDelete(t *testing.T) {
+	ctx, api := newTestMessageContext(t, New().On("test", nil))
+	ctx.responder.(*MessageResponder).EphemeralDeleteAfter = time.Millisecond
+
+	if _, err := ctx.ReplyEphemeral("secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	requests := api.Requests()
+
+	if len(requests) != 2 {
+		t.Fatal("Expected 2 requests, got:", requests)
+	}
+
+	if requests[0].Method != "POST" || requests[0].Path != "/api/v9/channels/6/messages" {
+		t.Fatal("Expected a reply, got:", requests[0])
+	}
+
+	if requests[1].Method != "DELETE" || requests[1].Path != "/api/v9/channels/2/messages/1" {
+		t.Fatal("Expected the reply to be deleted, got:", requests[1])
+	}
+}
+
+func TestMessageResponder_ReplyEphemeralDeleteError(t *testing.T) {
+	errs := make(chan error, 1)
+
+	r := New().SetErrorHandler(func(ctx *Context, err error) {
+		errs <- err
+	})
+
+	ctx, api := newTestMessageContext(t, r.On("test", nil))
+	ctx.responder.(*MessageResponder).EphemeralDeleteAfter = time.Millisecond
+
+	api.RespondStatus("DELETE /api/v9/channels/2/messages/1", http.StatusNotFound, `{"message":"Unknown Message","code":10008}`)
+
+	if _, err := ctx.ReplyEphemeral("secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatal("Expected a delete error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the delete error to be passed to the error handler")
+	}
+}
+
+func TestMessageResponder_ReplyEphemeralDM(t *testing.T) {
+	ctx, api := newTestMessageContext(t, New().On("test", nil))
+	ctx.responder.(*MessageResponder).EphemeralFallback = EphemeralDM
+
+	if _, err := ctx.ReplyEphemeral("secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 2 {
+		t.Fatal("Expected 2 requests, got:", requests)
+	}
+
+	if requests[0].Path != "/api/v9/users/@me/channels" {
+		t.Fatal("Expected a DM channel to be created, got:", requests[0])
+	}
+
+	if requests[1].Path != "/api/v9/channels/1/messages" {
+		t.Fatal("Expected a direct message, got:", requests[1])
+	}
+}
+
+func TestMessageResponder_EditResponse(t *testing.T) {
+	ctx, api := newTestMessageContext(t, New().On("test", nil))
+
+	if _, err := ctx.EditResponse("nothing"); err != ErrNoResponse {
+		t.Fatal("Expected ErrNoResponse, got:", err)
+	}
+
+	if _, err := ctx.Reply("Working..."); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ctx.EditResponse("Done!"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ctx.DeleteResponse(); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 3 {
+		t.Fatal("Expected 3 requests, got:", requests)
+	}
+
+	if requests[1].Method != "PATCH" || requests[1].Path != "/api/v9/channels/2/messages/1" {
+		t.Fatal("Expected the response to be edited, got:", requests[1])
+	}
+
+	if requests[2].Method != "DELETE" || requests[2].Path != "/api/v9/channels/2/messages/1" {
+		t.Fatal("Expected the response to be deleted, got:", requests[2])
+	}
+}
@@ -11,11 +11,31 @@ import (
 var (
 	userMentionRegexp    = regexp.MustCompile("<@!?(\\d+)>")
 	channelMentionRegexp = regexp.MustCompile("<#(\\d+)>")
+	roleMentionRegexp    = regexp.MustCompile("<@&(\\d+)>")
 )
 
 // Handler is a command handler.
 type Handler func(*Context)
 
+// HandlerFunc is a command handler which returns an error.
+// Returned errors are passed to the route's ErrorHandler.
+type HandlerFunc func(*Context) error
+
+// ErrorHandler is called with errors returned from handlers and middleware.
+type ErrorHandler func(*Context, error)
+
+// wrapHandler converts a Handler into a HandlerFunc
+func wrapHandler(f Handler) HandlerFunc {
+	if f == nil {
+		return nil
+	}
+
+	return func(ctx *Context) error {
+		f(ctx)
+		return nil
+	}
+}
+
 // FindOpts represents options for FindComplex. Default is just Args in Find.
 type FindOpts struct {
 	Args      []string
@@ -24,26 +44,36 @@ type FindOpts struct {
 
 // Route type contains information about a route, such as middleware, routes, etc
 type Route struct {
-	parent     *Route
-	handler    Handler
-	middleware []MiddlewareFunc
-	routes     map[string]*Route
-	aliases    map[string]string
-	export     bool
-
-	Name                  string
-	Usage                 string
-	Description           string
-	Arguments             map[string]*Argument
-	ArgumentCount         int
-	RequiredArgumentCount int
+	parent       *Route
+	handler      HandlerFunc
+	middleware   []ErrorMiddlewareFunc
+	checks       []Check
+	errorHandler ErrorHandler
+	translator   Translator
+	routes       map[string]*Route
+	menus        map[discord.CommandType]map[string]*Route
+	aliases      map[string]string
+	export       bool
+	commandType  discord.CommandType
+
+	Name                     string
+	Usage                    string
+	Description              string
+	NameLocalizations        map[string]string
+	DescriptionLocalizations map[string]string
+	DefaultMemberPermissions *discord.Permissions
+	DMPermission             *bool
+	Arguments                map[string]*Argument
+	ArgumentCount            int
+	RequiredArgumentCount    int
 }
 
 // New creates a new, empty route.
 func New() *Route {
 	return &Route{
-		middleware: make([]MiddlewareFunc, 0),
+		middleware: make([]ErrorMiddlewareFunc, 0),
 		routes:     make(map[string]*Route),
+		menus:      make(map[discord.CommandType]map[string]*Route),
 		aliases:    make(map[string]string),
 	}
 }
@@ -92,6 +122,7 @@ func (r *Route) Autocomplete(name string, f AutocompleteHandler) *Route {
 
 // Add adds a sub route to this route.
 func (r *Route) Add(n *Route) *Route {
+	n.parent = r
 	r.routes[n.Name] = n
 	return r
 }
@@ -118,18 +149,29 @@ func (r *Route) Export(export bool) *Route {
 
 // On adds a handler for a specific command.
 // Signature can be a simple command, or a string like the following:
-//  	command <arg1> <arg2> [arg3] [#channel] [@user]
+//
+//	command <arg1> <arg2> [arg3] [#channel] [@user]
+//
 // The library will automatically parse and validate the required arguments.
 // <> means an argument will be required, [] says it's optional
 // As well as required and optional types, you can use # and @ to signify
 // That routes must match a valid user or channel.
 func (r *Route) On(signature string, f Handler) *Route {
+	return r.Handle(signature, wrapHandler(f))
+}
+
+// Handle adds an error returning handler for a specific command.
+// Signatures are the same as On, and returned errors are passed to the route's ErrorHandler.
+func (r *Route) Handle(signature string, f HandlerFunc) *Route {
 	rt := New()
 	rt.parent = r
 	rt.handler = f
 	rt.export = r.export
+	rt.checks = append(rt.checks, r.checks...)
+	rt.DefaultMemberPermissions = r.DefaultMemberPermissions
+	rt.DMPermission = r.DMPermission
 	parseSignature(rt, signature)
-	r.routes[rt.Name] = rt.Use(r.middleware...)
+	r.routes[rt.Name] = rt.UseError(r.middleware...)
 	return rt
 }
 
@@ -137,7 +179,10 @@ func (r *Route) On(signature string, f Handler) *Route {
 // All routes will be copied into this route, with middleware applied.
 func (r *Route) Group(fn func(*Route)) *Route {
 	rt := New()
-	rt.Use(r.middleware...)
+	rt.UseError(r.middleware...)
+	rt.checks = append(rt.checks, r.checks...)
+	rt.DefaultMemberPermissions = r.DefaultMemberPermissions
+	rt.DMPermission = r.DMPermission
 	fn(rt)
 
 	for _, sub := range rt.routes {
@@ -153,15 +198,38 @@ func (r *Route) Group(fn func(*Route)) *Route {
 
 // Use applies middleware to this route. All sub-routes will also inherit this middleware.
 func (r *Route) Use(f ...MiddlewareFunc) *Route {
-	if r.middleware == nil {
-		r.middleware = f
-	} else {
-		r.middleware = append(r.middleware, f...)
+	for _, m := range f {
+		r.middleware = append(r.middleware, wrapMiddleware(m))
 	}
 
 	return r
 }
 
+// UseError applies error aware middleware to this route. All sub-routes will also inherit this middleware.
+func (r *Route) UseError(f ...ErrorMiddlewareFunc) *Route {
+	r.middleware = append(r.middleware, f...)
+
+	return r
+}
+
+// SetErrorHandler sets the handler for errors returned by this route's handlers.
+// Sub-routes without their own error handler will inherit it.
+func (r *Route) SetErrorHandler(h ErrorHandler) *Route {
+	r.errorHandler = h
+	return r
+}
+
+// ErrorHandler returns the error handler for this route, or the closest parent's error handler.
+func (r *Route) ErrorHandler() ErrorHandler {
+	for rt := r; rt != nil; rt = rt.parent {
+		if rt.errorHandler != nil {
+			return rt.errorHandler
+		}
+	}
+
+	return nil
+}
+
 // Find a route by arguments
 func (r *Route) Find(args ...string) *Route {
 	return r.FindComplex(FindOpts{Args: args})
@@ -208,11 +276,19 @@ func (r *Route) Call(ctx *Context) error {
 
 	ctx.route = r
 
+	if err := r.CanRun(ctx); err != nil {
+		if errorHandler := r.ErrorHandler(); errorHandler != nil {
+			errorHandler(ctx, err)
+		}
+
+		return err
+	}
+
 	if r.ArgumentCount > 0 {
 		// Arguments are cached, construct usage
 		if err := r.Validate(ctx); err != nil {
 			if err == UsageError {
-				_, err = ctx.Reply("Usage: " + ctx.Prefix + r.Usage)
+				_, err = ctx.Reply(ctx.T(MessageUsage, ctx.Prefix+r.Usage))
 			} else {
 				_, err = ctx.Reply(err.Error())
 			}
@@ -226,7 +302,13 @@ func (r *Route) Call(ctx *Context) error {
 		handler = v(handler)
 	}
 
-	handler(ctx)
+	if err := handler(ctx); err != nil {
+		if errorHandler := r.ErrorHandler(); errorHandler != nil {
+			errorHandler(ctx, err)
+		}
+
+		return err
+	}
 
 	return nil
 }
@@ -1,6 +1,10 @@
 package router
 
-import "testing"
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"testing"
+)
 
 func TestRoute_Path(t *testing.T) {
 	parent := New()
@@ -41,3 +45,201 @@ func TestRoute_Validate(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRoute_ErrorHandler(t *testing.T) {
+	expected := errors.New("handler error")
+
+	var handled error
+
+	parent := New().SetErrorHandler(func(ctx *Context, err error) {
+		handled = err
+	})
+
+	parent.Group(func(r *Route) {
+		r.Use(func(fn Handler) Handler {
+			return func(ctx *Context) {
+				fn(ctx)
+			}
+		})
+
+		r.Handle("fail", func(ctx *Context) error {
+			return expected
+		})
+	})
+
+	r := parent.Find("fail")
+
+	if r == nil {
+		t.Fatal("Expected to find route fail")
+	}
+
+	if err := r.Call(&Context{VariableBag: NewVariableBag()}); err != expected {
+		t.Fatal("Expected Call to return handler error, got:", err)
+	}
+
+	if handled != expected {
+		t.Fatal("Expected error handler to be called with handler error, got:", handled)
+	}
+}
+
+func TestRoute_ValidateValues(t *testing.T) {
+	r := New().On("roll <count int> <modifier float> [verbose bool]", nil)
+
+	ctx := &Context{
+		route:         r,
+		ArgumentCount: 3,
+		Arguments:     []string{"3", "1.5", "true"},
+	}
+
+	if err := r.Validate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if count, ok := ctx.IntArg("count"); !ok || count != 3 {
+		t.Fatal("Expected count to be 3, got:", count)
+	}
+
+	if modifier, ok := ctx.FloatArg("modifier"); !ok || modifier != 1.5 {
+		t.Fatal("Expected modifier to be 1.5, got:", modifier)
+	}
+
+	if verbose, ok := ctx.BoolArg("verbose"); !ok || !verbose {
+		t.Fatal("Expected verbose to be true")
+	}
+
+	if _, ok := ctx.FloatArg("count"); ok {
+		t.Fatal("Expected count to not be a float")
+	}
+}
+
+func TestRoute_ValidateLimits(t *testing.T) {
+	r := New().On("limits <count int min:1 max:10> [name minlen:2 maxlen:4] [ratio float max:1]", nil)
+
+	ctx := &Context{
+		route:         r,
+		ArgumentCount: 2,
+		Arguments:     []string{"10", "abcd"},
+	}
+
+	if err := r.Validate(ctx); err != nil {
+		t.Fatal("Expected inclusive limits to pass, got:", err)
+	}
+
+	ctx = &Context{
+		route:         r,
+		ArgumentCount: 3,
+		Arguments:     []string{"11", "a", "2.5"},
+	}
+
+	err := r.Validate(ctx)
+
+	errs, ok := err.(ValidationErrors)
+
+	if !ok {
+		t.Fatal("Expected ValidationErrors, got:", err)
+	}
+
+	if len(errs) != 3 {
+		t.Fatal("Expected 3 failing arguments, got:", errs)
+	}
+
+	for i, name := range []string{"count", "name", "ratio"} {
+		if errs[i].Argument.Name != name {
+			t.Fatalf("Expected error %d to be for %s, got: %s", i, name, errs[i].Argument.Name)
+		}
+	}
+}
+
+func TestRoute_ValidateDiscordTypes(t *testing.T) {
+	r := New().On("assign <&role> <who mentionable> <#ch types:text> [file attachment]", nil)
+
+	ctx, api := newTestMessageContext(t, r)
+
+	api.Respond("GET /api/v9/guilds/5/roles", `[{"id":"20","name":"mods"}]`)
+	api.Respond("GET /api/v9/channels/9", `{"id":"9","type":2,"guild_id":"5"}`)
+
+	ctx.Message.Attachments = []discord.Attachment{{ID: 30, Filename: "test.txt"}}
+	ctx.ArgumentCount = 3
+	ctx.Arguments = []string{"<@&20>", "<@&20>", "<#9>"}
+
+	err := r.Validate(ctx)
+
+	errs, ok := err.(ValidationErrors)
+
+	if !ok || len(errs) != 1 || errs[0].Argument.Name != "ch" || errs[0].Error() != "ch must be a text channel." {
+		t.Fatal("Expected only the voice channel to fail, got:", err)
+	}
+
+	if role, ok := ctx.RoleArg("role"); !ok || role.Name != "mods" {
+		t.Fatal("Expected role to be mods, got:", role)
+	}
+
+	if who, ok := ctx.MentionableArg("who"); !ok || who.Role == nil || who.ID != 20 {
+		t.Fatal("Expected who to be the mods role, got:", who)
+	}
+
+	if file, ok := ctx.AttachmentArg("file"); !ok || file.Filename != "test.txt" {
+		t.Fatal("Expected file to be the message attachment, got:", file)
+	}
+}
+
+func TestRoute_ValidateAttachmentBeforeText(t *testing.T) {
+	r := New().On("upload <file attachment> <title> [other attachment]", nil)
+
+	if r.Arguments["title"].Index != 0 || r.Arguments["other"].Index != 1 || r.RequiredArgumentCount != 1 {
+		t.Fatal("Expected attachments to be indexed separately, got:", r.Arguments["title"].Index, r.Arguments["other"].Index, r.RequiredArgumentCount)
+	}
+
+	ctx, _ := newTestMessageContext(t, r)
+
+	ctx.Message.Attachments = []discord.Attachment{{ID: 30, Filename: "a.txt"}, {ID: 31, Filename: "b.txt"}}
+	ctx.ArgumentCount = 1
+	ctx.Arguments = []string{"hello"}
+
+	if err := r.Validate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if title := ctx.Arg("title"); title != "hello" {
+		t.Fatal("Expected title to be hello, got:", title)
+	}
+
+	if file, ok := ctx.AttachmentArg("other"); !ok || file.Filename != "b.txt" {
+		t.Fatal("Expected other to be the second attachment, got:", file)
+	}
+
+	if args := r.sortedArguments(); args[0].Name != "file" || args[1].Name != "title" || args[2].Name != "other" {
+		t.Fatal("Expected arguments in signature order")
+	}
+}
+
+func TestRoute_Check(t *testing.T) {
+	errDenied := errors.New("denied")
+
+	var handled error
+	called := false
+
+	r := New().SetErrorHandler(func(ctx *Context, err error) {
+		handled = err
+	})
+
+	r.Check(func(ctx *Context) error {
+		if ctx.User.ID != 1 {
+			return errDenied
+		}
+
+		return nil
+	})
+
+	rt := r.On("test", func(ctx *Context) {
+		called = true
+	})
+
+	if err := rt.Call(&Context{}); err != errDenied || handled != errDenied || called {
+		t.Fatal("Expected the check to stop the route, got:", err, handled, called)
+	}
+
+	if err := rt.Call(&Context{User: discord.User{ID: 1}}); err != nil || !called {
+		t.Fatal("Expected the route to run, got:", err)
+	}
+}
@@ -0,0 +1,149 @@
+package router
+
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/gateway"
+	"github.com/diamondburned/arikawa/v3/state"
+	"github.com/diamondburned/arikawa/v3/utils/httputil/httpdriver"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// testRequest is a request received by the test API
+type testRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// testAPI records requests to the Discord API and responds with a fixed body,
+// unless a response is set for the request's method and path.
+type testAPI struct {
+	mu        sync.Mutex
+	requests  []testRequest
+	responses map[string]string
+	statuses  map[string]int
+}
+
+// Respond sets the response body for a method and path, such as "GET /api/v9/users/@me"
+func (a *testAPI) Respond(route, body string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.responses[route] = body
+}
+
+// RespondStatus sets the response status and body for a method and path, such as to fail a request
+func (a *testAPI) RespondStatus(route string, status int, body string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.responses[route] = body
+	a.statuses[route] = status
+}
+
+func (a *testAPI) Requests() []testRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]testRequest(nil), a.requests...)
+}
+
+// newTestState creates a state which sends all API requests to a testAPI
+func newTestState(t *testing.T) (*state.State, *testAPI) {
+	api := &testAPI{responses: make(map[string]string), statuses: make(map[string]int)}
+
+	s := state.New("Bot test")
+
+	s.Client.Client.Client = httpdriver.WrapClient(http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			var body []byte
+
+			if r.Body != nil {
+				body, _ = ioutil.ReadAll(r.Body)
+			}
+
+			api.mu.Lock()
+			api.requests = append(api.requests, testRequest{Method: r.Method, Path: r.URL.Path, Body: string(body)})
+			response, ok := api.responses[r.Method+" "+r.URL.Path]
+			status := api.statuses[r.Method+" "+r.URL.Path]
+			api.mu.Unlock()
+
+			rec := httptest.NewRecorder()
+
+			if ok {
+				rec.Header().Set("Content-Type", "application/json")
+
+				if status != 0 {
+					rec.WriteHeader(status)
+				}
+
+				rec.WriteString(response)
+			} else if r.Method == http.MethodDelete || strings.HasSuffix(r.URL.Path, "/callback") {
+				rec.WriteHeader(http.StatusNoContent)
+			} else {
+				rec.Header().Set("Content-Type", "application/json")
+				rec.WriteString(`{"id":"1","channel_id":"2","content":"test"}`)
+			}
+
+			return rec.Result(), nil
+		}),
+	})
+
+	// The bot user is cached, so it isn't requested
+	s.Cabinet.MyselfSet(discord.User{ID: 1, Bot: true}, false)
+
+	return s, api
+}
+
+// newTestInteractionContext creates a context for a command interaction using a test state
+func newTestInteractionContext(t *testing.T, r *Route) (*Context, *testAPI) {
+	s, api := newTestState(t)
+
+	ctx := &Context{
+		VariableBag: NewVariableBag(),
+		route:       r,
+		Session:     s,
+		Interaction: &gateway.InteractionCreateEvent{
+			InteractionEvent: discord.InteractionEvent{
+				ID:    3,
+				AppID: 4,
+				Token: "token",
+				Data:  &discord.CommandInteraction{Name: r.Name},
+			},
+		},
+	}
+
+	ctx.responder = &InteractionResponder{ctx: ctx}
+
+	return ctx, api
+}
+
+// newTestMessageContext creates a context for a guild message using a test state
+func newTestMessageContext(t *testing.T, r *Route) (*Context, *testAPI) {
+	s, api := newTestState(t)
+
+	ctx := &Context{
+		VariableBag: NewVariableBag(),
+		route:       r,
+		Session:     s,
+		Guild:       &discord.Guild{ID: 5},
+		Channel:     &discord.Channel{ID: 6, GuildID: 5, Type: discord.GuildText},
+		Message:     discord.Message{ID: 7, ChannelID: 6, GuildID: 5},
+		User:        discord.User{ID: 8},
+	}
+
+	ctx.responder = &MessageResponder{ctx: ctx}
+
+	return ctx, api
+}
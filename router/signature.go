@@ -2,6 +2,7 @@ package router
 
 import (
 	"encoding/csv"
+	"errors"
 	"github.com/diamondburned/arikawa/v3/discord"
 	"regexp"
 	"strconv"
@@ -15,17 +16,51 @@ func (t ArgumentType) DiscordType() discord.CommandOptionType {
 	switch t {
 	case ArgumentTypeInt:
 		return discord.IntegerOptionType
+	case ArgumentTypeFloat:
+		return discord.NumberOptionType
 	case ArgumentTypeBool:
 		return discord.BooleanOptionType
 	case ArgumentTypeUserMention:
 		return discord.UserOptionType
 	case ArgumentTypeChannelMention:
 		return discord.ChannelOptionType
+	case ArgumentTypeRole:
+		return discord.RoleOptionType
+	case ArgumentTypeMentionable:
+		return discord.MentionableOptionType
+	case ArgumentTypeAttachment:
+		return AttachmentOptionType
 	default:
 		return discord.StringOptionType
 	}
 }
 
+// String returns a readable name for the argument type
+func (t ArgumentType) String() string {
+	switch t {
+	case ArgumentTypeInt:
+		return "integer"
+	case ArgumentTypeFloat:
+		return "number"
+	case ArgumentTypeBool:
+		return "true/false"
+	case ArgumentTypeEmoji:
+		return "emoji"
+	case ArgumentTypeUserMention:
+		return "user"
+	case ArgumentTypeChannelMention:
+		return "channel"
+	case ArgumentTypeRole:
+		return "role"
+	case ArgumentTypeMentionable:
+		return "user or role"
+	case ArgumentTypeAttachment:
+		return "attachment"
+	default:
+		return "text"
+	}
+}
+
 const (
 	ArgumentTypeBasic ArgumentType = iota
 	ArgumentTypeInt
@@ -34,14 +69,32 @@ const (
 	ArgumentTypeEmoji
 	ArgumentTypeUserMention
 	ArgumentTypeChannelMention
+	ArgumentTypeRole
+	ArgumentTypeMentionable
+	ArgumentTypeAttachment
 )
 
 const (
-	argInt   = "int"
-	argFloat = "float"
-	argBool  = "bool"
+	argInt         = "int"
+	argFloat       = "float"
+	argBool        = "bool"
+	argMentionable = "mentionable"
+	argAttachment  = "attachment"
 )
 
+// channelTypeNames maps the names used by the types attribute to channel types
+var channelTypeNames = map[string]discord.ChannelType{
+	"text":           discord.GuildText,
+	"voice":          discord.GuildVoice,
+	"category":       discord.GuildCategory,
+	"news":           discord.GuildNews,
+	"store":          discord.GuildStore,
+	"news_thread":    discord.GuildNewsThread,
+	"public_thread":  discord.GuildPublicThread,
+	"private_thread": discord.GuildPrivateThread,
+	"stage":          discord.GuildStageVoice,
+}
+
 // parseSignature parses a route's signature
 func parseSignature(r *Route, signature string) *Route {
 	r.Name = signature
@@ -61,7 +114,7 @@ func parseSignature(r *Route, signature string) *Route {
 
 		var name string
 		var f []string
-		var index int
+		var index, textIndex, attachmentIndex int
 
 		for {
 			if len(str) == 0 {
@@ -105,23 +158,39 @@ func parseSignature(r *Route, signature string) *Route {
 						} else if name[0] == '#' {
 							t = ArgumentTypeChannelMention
 							name = name[1:]
+						} else if name[0] == '&' {
+							t = ArgumentTypeRole
+							name = name[1:]
 						}
 
 						arg := &Argument{
 							Type:     t,
-							Index:    index,
 							Name:     name,
 							Required: required,
+							position: index,
 						}
 
 						if len(f) > 1 {
-							err := parseArgumentAttributes(arg, f)
+							err := parseArgumentAttributes(arg, f[1:])
 
 							if err != nil {
 								panic("Invalid signature: " + err.Error())
 							}
 						}
 
+						// Attachments aren't part of the text arguments, so they're indexed and checked separately
+						if arg.Type == ArgumentTypeAttachment {
+							arg.Index = attachmentIndex
+							attachmentIndex++
+
+							if required {
+								r.RequiredArgumentCount--
+							}
+						} else {
+							arg.Index = textIndex
+							textIndex++
+						}
+
 						r.Arguments[name] = arg
 
 						index++
@@ -142,20 +211,25 @@ var (
 	prefixRe = regexp.MustCompile("([a-zA-Z0-9]+):(.*)")
 )
 
+// parseArgumentAttributes parses the fields after an argument's name.
+// The type is set first, so limits are parsed as the argument's type wherever they appear.
 func parseArgumentAttributes(arg *Argument, f []string) error {
 	for _, field := range f {
 		switch field {
 		case argInt:
 			arg.Type = ArgumentTypeInt
-			continue
 		case argFloat:
 			arg.Type = ArgumentTypeFloat
-			continue
 		case argBool:
 			arg.Type = ArgumentTypeBool
-			continue
+		case argMentionable:
+			arg.Type = ArgumentTypeMentionable
+		case argAttachment:
+			arg.Type = ArgumentTypeAttachment
 		}
+	}
 
+	for _, field := range f {
 		m := prefixRe.FindStringSubmatch(field)
 
 		if m == nil {
@@ -197,6 +271,32 @@ func parseArgumentAttributes(arg *Argument, f []string) error {
 			}
 
 			arg.Max = max
+		case "minlen":
+			minLength, err := strconv.Atoi(m[2])
+
+			if err != nil {
+				return err
+			}
+
+			arg.MinLength = minLength
+		case "maxlen":
+			maxLength, err := strconv.Atoi(m[2])
+
+			if err != nil {
+				return err
+			}
+
+			arg.MaxLength = maxLength
+		case "types":
+			for _, name := range strings.Split(m[2], ",") {
+				t, ok := channelTypeNames[name]
+
+				if !ok {
+					return errors.New("unknown channel type " + name)
+				}
+
+				arg.ChannelTypes = append(arg.ChannelTypes, t)
+			}
 		}
 	}
 
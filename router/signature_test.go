@@ -1,11 +1,14 @@
 package router
 
-import "testing"
+import (
+	"github.com/diamondburned/arikawa/v3/discord"
+	"testing"
+)
 
 func TestParseSignature(t *testing.T) {
 	r := New()
 
-	parseSignature(r, "test <string arg> <:emoji arg> <@mention arg> <#channel arg> <intarg int min:1> [optional val int min:1] <floatarg float> <boolarg bool> [optional]")
+	parseSignature(r, "test <string arg> <:emoji arg> <@mention arg> <#channel arg> <intarg int min:1> [optionalval int min:1] <floatarg float> <boolarg bool> [optional]")
 
 	if r.ArgumentCount < 8 {
 		t.Fatal("Expected 8 arguments")
@@ -15,19 +18,19 @@ func TestParseSignature(t *testing.T) {
 		t.Fatal("Expected optional argument to not be required")
 	}
 
-	if basic, exists := r.Arguments["string arg"]; !exists || basic.Type != ArgumentTypeBasic {
+	if basic, exists := r.Arguments["string"]; !exists || basic.Type != ArgumentTypeBasic {
 		t.Fatal("Expected string arg to be type Basic")
 	}
 
-	if emoji, exists := r.Arguments["emoji arg"]; !exists || emoji.Type != ArgumentTypeEmoji {
+	if emoji, exists := r.Arguments["emoji"]; !exists || emoji.Type != ArgumentTypeEmoji {
 		t.Fatal("Expected emoji arg to be type Emoji")
 	}
 
-	if mention, exists := r.Arguments["mention arg"]; !exists || mention.Type != ArgumentTypeUserMention {
+	if mention, exists := r.Arguments["mention"]; !exists || mention.Type != ArgumentTypeUserMention {
 		t.Fatal("Expected mention arg to be type UserMention")
 	}
 
-	if channel, exists := r.Arguments["channel arg"]; !exists || channel.Type != ArgumentTypeChannelMention {
+	if channel, exists := r.Arguments["channel"]; !exists || channel.Type != ArgumentTypeChannelMention {
 		t.Fatal("Expected channel arg to be type Channel")
 	}
 
@@ -35,7 +38,7 @@ func TestParseSignature(t *testing.T) {
 		t.Fatal("Expected intarg to be type int")
 	}
 
-	if optionalIntArg, exists := r.Arguments["optional val"]; !exists || optionalIntArg.Type != ArgumentTypeInt {
+	if optionalIntArg, exists := r.Arguments["optionalval"]; !exists || optionalIntArg.Type != ArgumentTypeInt {
 		t.Fatal("Expected optional val to be type int")
 	}
 
@@ -47,3 +50,61 @@ func TestParseSignature(t *testing.T) {
 		t.Fatal("Expected boolarg to be type bool")
 	}
 }
+
+func TestParseSignature_LimitBeforeType(t *testing.T) {
+	r := New()
+
+	parseSignature(r, "test <n min:1 max:10 int> [ratio max:0.5 float]")
+
+	if n := r.Arguments["n"]; n == nil || n.Type != ArgumentTypeInt || n.Min != int64(1) || n.Max != int64(10) {
+		t.Fatal("Expected n to be an int between 1 and 10, got:", n)
+	}
+
+	if ratio := r.Arguments["ratio"]; ratio == nil || ratio.Type != ArgumentTypeFloat || ratio.Max != 0.5 {
+		t.Fatal("Expected ratio to be a float with a max of 0.5, got:", ratio)
+	}
+}
+
+func TestParseSignature_Length(t *testing.T) {
+	r := New()
+
+	parseSignature(r, "test <name minlen:2 maxlen:32>")
+
+	arg, exists := r.Arguments["name"]
+
+	if !exists {
+		t.Fatal("Expected name argument")
+	}
+
+	if arg.MinLength != 2 || arg.MaxLength != 32 {
+		t.Fatal("Expected length limits 2 and 32, got:", arg.MinLength, arg.MaxLength)
+	}
+}
+
+func TestParseSignature_DiscordTypes(t *testing.T) {
+	r := New()
+
+	parseSignature(r, "test <&role> <target mentionable> [#ch types:text,voice] <file attachment>")
+
+	if r.ArgumentCount != 4 || r.RequiredArgumentCount != 2 {
+		t.Fatal("Expected 4 arguments with 2 required text arguments, got:", r.ArgumentCount, r.RequiredArgumentCount)
+	}
+
+	if role := r.Arguments["role"]; role == nil || role.Type != ArgumentTypeRole || role.Type.DiscordType() != discord.RoleOptionType {
+		t.Fatal("Expected role to be type Role")
+	}
+
+	if target := r.Arguments["target"]; target == nil || target.Type != ArgumentTypeMentionable {
+		t.Fatal("Expected target to be type Mentionable")
+	}
+
+	ch := r.Arguments["ch"]
+
+	if ch == nil || ch.Type != ArgumentTypeChannelMention || len(ch.ChannelTypes) != 2 || ch.ChannelTypes[1] != discord.GuildVoice {
+		t.Fatal("Expected ch to be a text or voice channel, got:", ch)
+	}
+
+	if file := r.Arguments["file"]; file == nil || !file.Required || file.Type.DiscordType() != AttachmentOptionType {
+		t.Fatal("Expected file to be a required attachment")
+	}
+}
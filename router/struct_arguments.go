@@ -0,0 +1,286 @@
+package router
+
+import (
+	"errors"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const structTag = "astral"
+
+var (
+	contextType = reflect.TypeOf(&Context{})
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	userType    = reflect.TypeOf(discord.User{})
+	channelType = reflect.TypeOf(discord.Channel{})
+	emojiType   = reflect.TypeOf(discord.Emoji{})
+	roleType    = reflect.TypeOf(discord.Role{})
+
+	mentionableType = reflect.TypeOf(Mentionable{})
+	attachmentType  = reflect.TypeOf(discord.Attachment{})
+)
+
+// structArgument maps a struct field to a route argument
+type structArgument struct {
+	field       int
+	name        string
+	description string
+	choices     []string
+}
+
+// OnArgs adds a handler for a specific command, with arguments defined by a struct.
+// The handler must be a func(*Context, T) or func(*Context, T) error, where T is a struct (or pointer to one).
+// Each field is an argument, configured by its tag:
+//  	Count int `astral:"count,required,min=1,max=10,desc=Number of dice"`
+// Supported options are required, min, max, minlen, maxlen, choices and channel types (both separated by |) and desc.
+// As descriptions may contain commas, desc must be the last option.
+// The argument type is derived from the field type, including named types: string, int, float, bool, discord.User,
+// discord.Channel, discord.Emoji, discord.Role, Mentionable or discord.Attachment. Fields without a tag are ignored,
+// and tagged fields must be exported.
+// Values which overflow a narrower field type, such as int8, fail validation.
+func (r *Route) OnArgs(name string, f interface{}) *Route {
+	fn := reflect.ValueOf(f)
+	fnType := fn.Type()
+
+	if fnType.Kind() != reflect.Func || fnType.NumIn() != 2 || fnType.In(0) != contextType ||
+		fnType.NumOut() > 1 || fnType.NumOut() == 1 && fnType.Out(0) != errorType {
+		panic("Invalid handler: expected func(*Context, T) or func(*Context, T) error")
+	}
+
+	argsType := fnType.In(1)
+	structType := argsType
+
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		panic("Invalid handler: arguments must be a struct")
+	}
+
+	signature, args, err := structSignature(name, structType)
+
+	if err != nil {
+		panic("Invalid arguments: " + err.Error())
+	}
+
+	rt := r.Handle(signature, func(ctx *Context) error {
+		v := reflect.New(structType)
+
+		if err := populateStruct(ctx, v.Elem(), args); err != nil {
+			_, err = ctx.Reply(err.Error())
+			return err
+		}
+
+		if argsType.Kind() != reflect.Ptr {
+			v = v.Elem()
+		}
+
+		ret := fn.Call([]reflect.Value{reflect.ValueOf(ctx), v})
+
+		if len(ret) > 0 && !ret[0].IsNil() {
+			return ret[0].Interface().(error)
+		}
+
+		return nil
+	})
+
+	for _, arg := range args {
+		argument := rt.Arguments[arg.name]
+		argument.Description = arg.description
+
+		if len(arg.choices) > 0 {
+			argument.Choices = make([]StringChoice, len(arg.choices))
+
+			for i, choice := range arg.choices {
+				argument.Choices[i] = StringChoice{Name: choice, Value: choice}
+			}
+		}
+	}
+
+	return rt
+}
+
+// structSignature builds a route signature from a struct's tagged fields
+func structSignature(name string, t reflect.Type) (string, []structArgument, error) {
+	signature := []string{name}
+	args := make([]structArgument, 0)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag, ok := field.Tag.Lookup(structTag)
+
+		if !ok || tag == "-" {
+			continue
+		}
+
+		if field.PkgPath != "" {
+			return "", nil, errors.New(field.Name + ": tagged field must be exported")
+		}
+
+		arg := structArgument{field: i}
+
+		prefix, attributes, err := structFieldType(field.Type)
+
+		if err != nil {
+			return "", nil, errors.New(field.Name + ": " + err.Error())
+		}
+
+		required := false
+		parts := strings.Split(tag, ",")
+
+		arg.name = parts[0]
+
+		if arg.name == "" {
+			arg.name = strings.ToLower(field.Name)
+		}
+
+		for j := 1; j < len(parts); j++ {
+			key, value := parts[j], ""
+
+			if idx := strings.Index(key, "="); idx != -1 {
+				key, value = key[:idx], key[idx+1:]
+			}
+
+			switch key {
+			case "required":
+				required = true
+			case "min", "max", "minlen", "maxlen":
+				attributes = append(attributes, key+":"+value)
+			case "types":
+				attributes = append(attributes, "types:"+strings.Replace(value, "|", ",", -1))
+			case "choices":
+				arg.choices = strings.Split(value, "|")
+			case "desc":
+				// Descriptions take the remainder of the tag
+				arg.description = strings.Join(append([]string{value}, parts[j+1:]...), ",")
+				j = len(parts)
+			default:
+				return "", nil, errors.New(field.Name + ": unknown option " + key)
+			}
+		}
+
+		str := strings.Join(append([]string{prefix + arg.name}, attributes...), " ")
+
+		if required {
+			signature = append(signature, "<"+str+">")
+		} else {
+			signature = append(signature, "["+str+"]")
+		}
+
+		args = append(args, arg)
+	}
+
+	return strings.Join(signature, " "), args, nil
+}
+
+// structFieldType returns the signature name prefix and type attributes for a field type
+func structFieldType(t reflect.Type) (string, []string, error) {
+	switch t {
+	case userType:
+		return "@", nil, nil
+	case channelType:
+		return "#", nil, nil
+	case emojiType:
+		return ":", nil, nil
+	case roleType:
+		return "&", nil, nil
+	case mentionableType:
+		return "", []string{argMentionable}, nil
+	case attachmentType:
+		return "", []string{argAttachment}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "", nil, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "", []string{argInt}, nil
+	case reflect.Float32, reflect.Float64:
+		return "", []string{argFloat}, nil
+	case reflect.Bool:
+		return "", []string{argBool}, nil
+	}
+
+	return "", nil, errors.New("unsupported argument type " + t.String())
+}
+
+// populateStruct sets the struct's fields from the context's argument values.
+// Values which don't fit in their field are returned as ValidationErrors.
+func populateStruct(ctx *Context, v reflect.Value, args []structArgument) error {
+	var errs ValidationErrors
+
+	for _, arg := range args {
+		value, ok := ctx.Value(arg.name)
+
+		if !ok {
+			continue
+		}
+
+		field := v.Field(arg.field)
+
+		switch val := value.(type) {
+		case int64:
+			if field.OverflowInt(val) {
+				errs = append(errs, ValidationError{
+					Argument: ctx.route.Arguments[arg.name],
+					Value:    strconv.FormatInt(val, 10),
+					Err:      intOverflowError(ctx, arg.name, field.Type(), val),
+				})
+				continue
+			}
+
+			field.SetInt(val)
+		case float64:
+			if field.OverflowFloat(val) {
+				errs = append(errs, ValidationError{
+					Argument: ctx.route.Arguments[arg.name],
+					Value:    strconv.FormatFloat(val, 'g', -1, 64),
+					Err:      floatOverflowError(ctx, arg.name, val),
+				})
+				continue
+			}
+
+			field.SetFloat(val)
+		default:
+			rv := reflect.ValueOf(value)
+
+			if rv.Type().AssignableTo(field.Type()) {
+				field.Set(rv)
+			} else if rv.Kind() == field.Kind() && rv.Type().ConvertibleTo(field.Type()) {
+				// Named string and bool types, such as type Color string
+				field.Set(rv.Convert(field.Type()))
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// intOverflowError creates a range error using the bounds of an integer type
+func intOverflowError(ctx *Context, name string, t reflect.Type, val int64) error {
+	max := int64(1)<<(t.Bits()-1) - 1
+
+	if val < 0 {
+		return ctx.translatedError(MessageMinInt, name, -max-1)
+	}
+
+	return ctx.translatedError(MessageMaxInt, name, max)
+}
+
+// floatOverflowError creates a range error using the bounds of a float32, the only float type which can overflow
+func floatOverflowError(ctx *Context, name string, val float64) error {
+	if val < 0 {
+		return ctx.translatedError(MessageMinFloat, name, -math.MaxFloat32)
+	}
+
+	return ctx.translatedError(MessageMaxFloat, name, math.MaxFloat32)
+}
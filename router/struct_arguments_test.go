@@ -0,0 +1,153 @@
+package router
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type rollArgs struct {
+	Count    int     `astral:"count,required,min=1,max=10,desc=Number of dice, at most 10"`
+	Sides    int64   `astral:",choices=6|20,desc=Dice sides"`
+	Label    string  `astral:"label,maxlen=8"`
+	Modifier float64 `astral:"modifier"`
+	ignored  string
+}
+
+func TestRoute_OnArgs(t *testing.T) {
+	var called rollArgs
+
+	r := New()
+
+	rt := r.OnArgs("roll", func(ctx *Context, args rollArgs) {
+		called = args
+	})
+
+	if rt.ArgumentCount != 4 || rt.RequiredArgumentCount != 1 {
+		t.Fatal("Expected 4 arguments with 1 required, got:", rt.ArgumentCount, rt.RequiredArgumentCount)
+	}
+
+	count := rt.Arguments["count"]
+
+	if count == nil || count.Type != ArgumentTypeInt || !count.Required || count.Min != int64(1) || count.Max != int64(10) {
+		t.Fatal("Expected count to be a required int between 1 and 10, got:", count)
+	}
+
+	if count.Description != "Number of dice, at most 10" {
+		t.Fatal("Expected description to contain the rest of the tag, got:", count.Description)
+	}
+
+	if sides := rt.Arguments["sides"]; sides == nil || len(sides.Choices) != 2 {
+		t.Fatal("Expected sides to have 2 choices, got:", sides)
+	}
+
+	if label := rt.Arguments["label"]; label == nil || label.Type != ArgumentTypeBasic || label.MaxLength != 8 {
+		t.Fatal("Expected label to be a string with a max length of 8, got:", label)
+	}
+
+	err := rt.Call(&Context{
+		ArgumentCount: 4,
+		Arguments:     []string{"3", "20", "test", "1.5"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if called.Count != 3 || called.Sides != 20 || called.Label != "test" || called.Modifier != 1.5 {
+		t.Fatal("Expected arguments to be populated, got:", called)
+	}
+}
+
+func TestRoute_OnArgsPointer(t *testing.T) {
+	var called *rollArgs
+
+	r := New()
+
+	rt := r.OnArgs("roll", func(ctx *Context, args *rollArgs) error {
+		called = args
+		return nil
+	})
+
+	if err := rt.Call(&Context{ArgumentCount: 1, Arguments: []string{"2"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if called == nil || called.Count != 2 || called.Label != "" {
+		t.Fatal("Expected arguments to be populated, got:", called)
+	}
+}
+
+func TestStructSignature_Unexported(t *testing.T) {
+	type args struct {
+		Count int    `astral:"count"`
+		label string `astral:"label"`
+	}
+
+	_, _, err := structSignature("test", reflect.TypeOf(args{}))
+
+	if err == nil || !strings.Contains(err.Error(), "label") {
+		t.Fatal("Expected error for unexported tagged field, got:", err)
+	}
+}
+
+func TestRoute_OnArgsOverflow(t *testing.T) {
+	type args struct {
+		Count int8    `astral:"count"`
+		Scale float32 `astral:"scale"`
+	}
+
+	called := false
+
+	r := New()
+
+	rt := r.OnArgs("scale", func(ctx *Context, args args) {
+		called = true
+	})
+
+	ctx, api := newTestMessageContext(t, rt)
+	ctx.ArgumentCount = 2
+	ctx.Arguments = []string{"200", "1e39"}
+
+	if err := rt.Call(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if called {
+		t.Fatal("Expected handler not to be called")
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != 1 || !strings.Contains(requests[0].Body, "count must be at most 127.") ||
+		!strings.Contains(requests[0].Body, "scale must be at most") {
+		t.Fatal("Expected overflow validation errors, got:", requests)
+	}
+}
+
+type color string
+
+type flag bool
+
+func TestRoute_OnArgsNamedTypes(t *testing.T) {
+	type args struct {
+		Color color `astral:"color,required"`
+		Loud  flag  `astral:"loud"`
+	}
+
+	var called args
+
+	r := New()
+
+	rt := r.OnArgs("paint", func(ctx *Context, args args) {
+		called = args
+	})
+
+	if err := rt.Call(&Context{ArgumentCount: 2, Arguments: []string{"red", "true"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if called.Color != "red" || !called.Loud {
+		t.Fatal("Expected named types to be populated, got:", called)
+	}
+}
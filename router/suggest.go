@@ -0,0 +1,121 @@
+package router
+
+import (
+	"sort"
+	"strings"
+)
+
+// Suggest finds routes similar to an unknown command, for "did you mean" suggestions.
+// Arguments are followed through the route tree as far as they match, and the next argument is
+// compared to the route names and aliases at that depth.
+// Suggestions are full commands within maxDistance edits, closest first.
+func (r *Route) Suggest(args []string, maxDistance int) []string {
+	rt := r
+	path := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		name := strings.ToLower(arg)
+
+		if alias, ok := rt.aliases[name]; ok {
+			name = alias
+		}
+
+		sub, ok := rt.routes[name]
+
+		if !ok {
+			return rt.suggestAt(path, name, maxDistance)
+		}
+
+		path = append(path, sub.Name)
+		rt = sub
+	}
+
+	return nil
+}
+
+// suggestAt compares a name against the sub routes and aliases of this route
+func (r *Route) suggestAt(path []string, name string, maxDistance int) []string {
+	distances := make(map[string]int)
+
+	add := func(candidate, routeName string) {
+		d := levenshtein(name, strings.ToLower(candidate))
+
+		if d > maxDistance {
+			return
+		}
+
+		command := strings.Join(append(append([]string{}, path...), routeName), " ")
+
+		if existing, ok := distances[command]; !ok || d < existing {
+			distances[command] = d
+		}
+	}
+
+	for routeName := range r.routes {
+		add(routeName, routeName)
+	}
+
+	for alias, routeName := range r.aliases {
+		add(alias, routeName)
+	}
+
+	suggestions := make([]string, 0, len(distances))
+
+	for command := range distances {
+		suggestions = append(suggestions, command)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := distances[suggestions[i]], distances[suggestions[j]]
+
+		if a != b {
+			return a < b
+		}
+
+		return suggestions[i] < suggestions[j]
+	})
+
+	return suggestions
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+
+	prev := make([]int, len(t)+1)
+	cur := make([]int, len(t)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		cur[0] = i
+
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(t)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+
+	return m
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		distance int
+	}{
+		{"ping", "ping", 0},
+		{"pnig", "ping", 2},
+		{"pin", "ping", 1},
+		{"", "ping", 4},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, test := range tests {
+		if d := levenshtein(test.a, test.b); d != test.distance {
+			t.Errorf("Expected distance between %s and %s to be %d, got: %d", test.a, test.b, test.distance, d)
+		}
+	}
+}
+
+func TestRoute_Suggest(t *testing.T) {
+	r := New()
+
+	r.On("ping", nil)
+	r.On("pong", nil)
+	r.On("purge <count int>", nil).Alias("clear")
+
+	admin := r.On("admin", nil)
+	admin.On("roles", nil)
+
+	if s := r.Suggest([]string{"pnig"}, 2); len(s) != 2 || s[0] != "ping" || s[1] != "pong" {
+		t.Fatal("Expected ping and pong, got:", s)
+	}
+
+	if s := r.Suggest([]string{"clr"}, 2); len(s) != 1 || s[0] != "purge" {
+		t.Fatal("Expected the alias to suggest purge, got:", s)
+	}
+
+	if s := r.Suggest([]string{"admin", "rolse", "extra"}, 2); len(s) != 1 || s[0] != "admin roles" {
+		t.Fatal("Expected admin roles, got:", s)
+	}
+
+	if s := r.Suggest([]string{"something"}, 2); len(s) != 0 {
+		t.Fatal("Expected no suggestions, got:", s)
+	}
+}
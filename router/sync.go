@@ -0,0 +1,328 @@
+package router
+
+import (
+	"encoding/json"
+	"github.com/diamondburned/arikawa/v3/discord"
+	"github.com/diamondburned/arikawa/v3/state"
+	"reflect"
+	"strings"
+)
+
+// SyncAction is an action taken on a command while syncing
+type SyncAction int
+
+const (
+	SyncCreate SyncAction = iota + 1
+	SyncUpdate
+	SyncDelete
+)
+
+func (a SyncAction) String() string {
+	switch a {
+	case SyncCreate:
+		return "create"
+	case SyncUpdate:
+		return "update"
+	case SyncDelete:
+		return "delete"
+	}
+
+	return "unknown"
+}
+
+// SyncOptions configures SyncCommands
+type SyncOptions struct {
+	// GuildID syncs guild commands instead of global commands when valid
+	GuildID discord.GuildID
+	// DryRun computes the changes without applying them
+	DryRun bool
+}
+
+// SyncChange is a change to a single command
+type SyncChange struct {
+	Action SyncAction
+	Type   discord.CommandType
+	Name   string
+	// ID is the existing command's ID for updates and deletes, or the created command's ID
+	ID discord.CommandID
+}
+
+func (c SyncChange) String() string {
+	return c.Action.String() + " " + c.Name
+}
+
+// SyncReport contains the changes made (or which would be made, for dry runs) by SyncCommands
+type SyncReport struct {
+	DryRun    bool
+	Changes   []SyncChange
+	Unchanged []string
+}
+
+// Changed returns true if any commands were (or would be) created, updated or deleted
+func (r *SyncReport) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+func (r *SyncReport) String() string {
+	if !r.Changed() {
+		return "no changes"
+	}
+
+	changes := make([]string, len(r.Changes))
+
+	for i, change := range r.Changes {
+		changes[i] = change.String()
+	}
+
+	return strings.Join(changes, ", ")
+}
+
+// commandKey identifies a command, as names are only unique per command type
+type commandKey struct {
+	Type discord.CommandType
+	Name string
+}
+
+// SyncCommands syncs the exported routes with the registered commands.
+// Unlike RegisterCommands, existing commands are compared to the route tree and only
+// changed commands are created, updated or deleted, keeping the IDs of unchanged commands.
+func SyncCommands(r *Route, s *state.State, appID discord.AppID, opts SyncOptions) (*SyncReport, error) {
+	commands, err := exportedCommands(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := registeredCommands(s, appID, opts.GuildID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SyncReport{
+		DryRun:    opts.DryRun,
+		Changes:   make([]SyncChange, 0),
+		Unchanged: make([]string, 0),
+	}
+
+	registered := make(map[commandKey]registeredCommand, len(existing))
+
+	for _, cmd := range existing {
+		registered[commandKey{Type: commandType(cmd.Type), Name: cmd.Name}] = cmd
+	}
+
+	for _, data := range commands {
+		key := commandKey{Type: commandType(data.Type), Name: data.Name}
+
+		cmd, exists := registered[key]
+
+		delete(registered, key)
+
+		if !exists {
+			change := SyncChange{Action: SyncCreate, Type: key.Type, Name: key.Name}
+
+			if !opts.DryRun {
+				var created *discord.Command
+
+				created, err = createCommand(s, appID, opts.GuildID, data)
+
+				if err != nil {
+					return report, registrationError{cause: err, route: r.commandRoute(key)}
+				}
+
+				change.ID = created.ID
+			}
+
+			report.Changes = append(report.Changes, change)
+			continue
+		}
+
+		equal, err := commandEqual(data, cmd.raw)
+
+		if err != nil {
+			return report, err
+		}
+
+		if equal {
+			report.Unchanged = append(report.Unchanged, key.Name)
+			continue
+		}
+
+		if !opts.DryRun {
+			_, err = editCommand(s, appID, opts.GuildID, cmd.ID, data)
+
+			if err != nil {
+				return report, registrationError{cause: err, route: r.commandRoute(key)}
+			}
+		}
+
+		report.Changes = append(report.Changes, SyncChange{Action: SyncUpdate, Type: key.Type, Name: key.Name, ID: cmd.ID})
+	}
+
+	// Anything left over is no longer exported
+	for _, cmd := range existing {
+		key := commandKey{Type: commandType(cmd.Type), Name: cmd.Name}
+
+		if _, exists := registered[key]; !exists {
+			continue
+		}
+
+		if !opts.DryRun {
+			if opts.GuildID.IsValid() {
+				err = s.DeleteGuildCommand(appID, opts.GuildID, cmd.ID)
+			} else {
+				err = s.DeleteCommand(appID, cmd.ID)
+			}
+
+			if err != nil {
+				return report, err
+			}
+		}
+
+		report.Changes = append(report.Changes, SyncChange{Action: SyncDelete, Type: key.Type, Name: key.Name, ID: cmd.ID})
+	}
+
+	return report, nil
+}
+
+// commandRoute finds the sub route or context menu route a command was created from
+func (r *Route) commandRoute(key commandKey) *Route {
+	if key.Type == discord.ChatInputCommand {
+		if rt, ok := r.routes[key.Name]; ok {
+			return rt
+		}
+	} else if rt := r.FindMenu(key.Type, key.Name); rt != nil {
+		return rt
+	}
+
+	return &Route{Name: key.Name}
+}
+
+// commandType returns the command type, which Discord defaults to chat input commands
+func commandType(t discord.CommandType) discord.CommandType {
+	if t == 0 {
+		return discord.ChatInputCommand
+	}
+
+	return t
+}
+
+// registeredCommand is a registered command, along with its raw JSON for comparison.
+// The raw JSON is used as arikawa doesn't decode every option type.
+type registeredCommand struct {
+	ID   discord.CommandID   `json:"id"`
+	Type discord.CommandType `json:"type"`
+	Name string              `json:"name"`
+	raw  json.RawMessage
+}
+
+// registeredCommands fetches the registered global or guild commands
+func registeredCommands(s *state.State, appID discord.AppID, guildID discord.GuildID) ([]registeredCommand, error) {
+	var raw []json.RawMessage
+
+	// Localizations are only included when requested
+	if err := s.RequestJSON(&raw, "GET", commandsEndpoint(appID, guildID)+"?with_localizations=true"); err != nil {
+		return nil, err
+	}
+
+	commands := make([]registeredCommand, len(raw))
+
+	for i, b := range raw {
+		if err := json.Unmarshal(b, &commands[i]); err != nil {
+			return nil, err
+		}
+
+		commands[i].raw = b
+	}
+
+	return commands, nil
+}
+
+// commandCompareKeys are the JSON keys compared to check if a command changed
+var commandCompareKeys = []string{"description", "options", "default_permission", "default_member_permissions", "name_localizations", "description_localizations"}
+
+// commandEqual compares command data to a registered command's JSON.
+// Both are normalized, as Discord omits empty and default values.
+func commandEqual(data CommandData, raw json.RawMessage) (bool, error) {
+	b, err := json.Marshal(data)
+
+	if err != nil {
+		return false, err
+	}
+
+	x, err := normalizeCommand(b)
+
+	if err != nil {
+		return false, err
+	}
+
+	y, err := normalizeCommand(raw)
+
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(x, y), nil
+}
+
+// normalizeCommand decodes the comparable parts of a command's JSON into a normalized value
+func normalizeCommand(b []byte) (interface{}, error) {
+	var v map[string]interface{}
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]interface{}, len(commandCompareKeys))
+
+	for _, key := range commandCompareKeys {
+		out[key] = normalizeJSON(v[key])
+	}
+
+	// DM permission defaults to true, so only a disabled DM permission is a change
+	out["dm_permission"] = v["dm_permission"] == false
+
+	return out, nil
+}
+
+// normalizeJSON removes null, false and empty values from decoded JSON
+func normalizeJSON(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+
+		for k, item := range val {
+			if item = normalizeJSON(item); item != nil {
+				out[k] = item
+			}
+		}
+
+		if len(out) == 0 {
+			return nil
+		}
+
+		return out
+	case []interface{}:
+		out := make([]interface{}, 0, len(val))
+
+		for _, item := range val {
+			out = append(out, normalizeJSON(item))
+		}
+
+		if len(out) == 0 {
+			return nil
+		}
+
+		return out
+	case bool:
+		if !val {
+			return nil
+		}
+	case string:
+		if val == "" {
+			return nil
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,138 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func testSyncRoute() *Route {
+	r := New()
+
+	r.On("ping", nil).Desc("Ping").Export(true)
+	r.On("echo", nil).Desc("Echoes back").Export(true)
+	r.On("new", nil).Desc("A new command").Export(true)
+	r.On("hidden", nil).Desc("Not exported")
+
+	return r
+}
+
+func testSyncState(t *testing.T) (*testAPI, func(dryRun bool) *SyncReport) {
+	s, api := newTestState(t)
+
+	api.Respond("GET /api/v9/applications/4/guilds/5/commands", `[
+		{"id":"10","type":1,"application_id":"4","name":"ping","description":"Ping","default_permission":true},
+		{"id":"11","type":1,"application_id":"4","name":"echo","description":"Echo","default_permission":true},
+		{"id":"12","type":1,"application_id":"4","name":"old","description":"Removed","default_permission":true}
+	]`)
+
+	return api, func(dryRun bool) *SyncReport {
+		report, err := SyncCommands(testSyncRoute(), s, 4, SyncOptions{GuildID: 5, DryRun: dryRun})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return report
+	}
+}
+
+func TestSyncCommands(t *testing.T) {
+	api, sync := testSyncState(t)
+
+	report := sync(false)
+
+	if report.String() != "update echo, create new, delete old" {
+		t.Fatal("Unexpected changes:", report)
+	}
+
+	if len(report.Unchanged) != 1 || report.Unchanged[0] != "ping" {
+		t.Fatal("Expected ping to be unchanged, got:", report.Unchanged)
+	}
+
+	expected := []testRequest{
+		{Method: http.MethodGet, Path: "/api/v9/applications/4/guilds/5/commands"},
+		{Method: http.MethodPatch, Path: "/api/v9/applications/4/guilds/5/commands/11"},
+		{Method: http.MethodPost, Path: "/api/v9/applications/4/guilds/5/commands"},
+		{Method: http.MethodDelete, Path: "/api/v9/applications/4/guilds/5/commands/12"},
+	}
+
+	requests := api.Requests()
+
+	if len(requests) != len(expected) {
+		t.Fatal("Expected", len(expected), "requests, got:", requests)
+	}
+
+	for i, req := range expected {
+		if requests[i].Method != req.Method || requests[i].Path != req.Path {
+			t.Fatal("Expected", req.Method, req.Path, "got:", requests[i].Method, requests[i].Path)
+		}
+	}
+}
+
+func TestSyncCommands_DryRun(t *testing.T) {
+	api, sync := testSyncState(t)
+
+	report := sync(true)
+
+	if !report.DryRun || len(report.Changes) != 3 {
+		t.Fatal("Expected 3 changes in a dry run, got:", report)
+	}
+
+	if requests := api.Requests(); len(requests) != 1 || requests[0].Method != http.MethodGet {
+		t.Fatal("Expected only the commands to be fetched, got:", requests)
+	}
+}
+
+func TestCommandEqual(t *testing.T) {
+	admin := testNestedRoute()
+
+	delete(admin.routes, "ping")
+
+	data, err := admin.toCommandData()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Discord omits empty values, such as required: false
+	raw := `{"id":"10","type":1,"name":"admin","description":"Admin commands","default_permission":true,"options":[
+		{"type":2,"name":"roles","description":"Manage roles","options":[
+			{"type":1,"name":"add","description":"Add a role","options":[{"type":3,"name":"name","description":"Role name","required":true}]},
+			{"type":1,"name":"remove","description":"Remove a role","options":[{"type":3,"name":"name","description":"Role name","required":true}]}
+		]}
+	]}`
+
+	if equal, err := commandEqual(data, json.RawMessage(raw)); err != nil || !equal {
+		t.Fatal("Expected registered command to equal its data, got:", equal, err)
+	}
+
+	changed := strings.Replace(raw, "Admin commands", "Changed", 1)
+
+	if equal, _ := commandEqual(data, json.RawMessage(changed)); equal {
+		t.Fatal("Expected changed description to be detected")
+	}
+}
+
+func TestCommandEqual_Attachment(t *testing.T) {
+	r := New()
+
+	upload := r.On("upload [file attachment]", nil).Desc("Upload a file").Argument("file", func(arg *Argument) {
+		arg.Description = "File to upload"
+	})
+
+	data, err := upload.toCommandData()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw := `{"id":"10","type":1,"name":"upload","description":"Upload a file","default_permission":true,"options":[
+		{"type":11,"name":"file","description":"File to upload"}
+	]}`
+
+	if equal, err := commandEqual(data, json.RawMessage(raw)); err != nil || !equal {
+		t.Fatal("Expected registered command to equal its data, got:", equal, err)
+	}
+}
@@ -2,11 +2,13 @@ package router
 
 import (
 	"errors"
-	"fmt"
 	"github.com/diamondburned/arikawa/v3/discord"
 	emoji "github.com/tmdvs/Go-Emoji-Utils"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 var (
@@ -26,178 +28,411 @@ func (i InvalidValueError) Error() string {
 	return "unknown argument value for " + i.Argument + ": " + i.Value
 }
 
+// ValidationError is a validation failure for a single argument
+type ValidationError struct {
+	Argument *Argument
+	Value    string
+	Err      error
+}
+
+// Error returns the underlying error's message
+func (e ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is a list of every argument which failed validation
+type ValidationErrors []ValidationError
+
+// Error joins all validation error messages, one per line
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "\n")
+}
+
 // Validate checks the context against the Route's defined arguments and ensures all required arguments
 // and types are satisfied.
+// Every supplied argument is checked, and failures are returned as ValidationErrors.
+// Validated arguments are stored on the context as typed values.
 func (r *Route) Validate(ctx *Context) error {
 	if ctx.ArgumentCount < r.RequiredArgumentCount {
 		return UsageError
 	}
 
-	var argValue string
-	var err error
+	var errs ValidationErrors
 
-	for _, arg := range r.Arguments {
-		if ctx.ArgumentCount < arg.Index+1 {
-			break
+	for _, arg := range r.sortedArguments() {
+		if arg.Type == ArgumentTypeAttachment {
+			if err := validateAttachment(ctx, arg); err != nil {
+				errs = append(errs, ValidationError{Argument: arg, Err: err})
+			}
+
+			continue
 		}
 
-		if !arg.Required {
+		if ctx.ArgumentCount < arg.Index+1 {
 			continue
 		}
 
-		argValue = ctx.Arguments[arg.Index]
+		argValue := ctx.Arguments[arg.Index]
 
 		if argValue == "" {
-			return fmt.Errorf("The %s argument is required.", arg.Name)
-		}
+			if arg.Required {
+				errs = append(errs, ValidationError{
+					Argument: arg,
+					Err:      ctx.translatedError(MessageRequired, arg.Name),
+				})
+			}
 
-		switch arg.Type {
-		case ArgumentTypeInt:
-			err = validateInt(ctx, arg, argValue)
-		case ArgumentTypeFloat:
-			err = validateFloat(ctx, arg, argValue)
-		case ArgumentTypeBool:
-			err = validateBool(ctx, arg, argValue)
-		case ArgumentTypeEmoji:
-			err = validateEmoji(ctx, arg, argValue)
-		case ArgumentTypeUserMention:
-			err = validateUserMention(ctx, arg, argValue)
-		case ArgumentTypeChannelMention:
-			err = validateChannelMention(ctx, arg, argValue)
+			continue
 		}
 
-		if err != nil {
-			return err
+		if err := validateArgument(ctx, arg, argValue); err != nil {
+			errs = append(errs, ValidationError{Argument: arg, Value: argValue, Err: err})
 		}
+	}
 
-		if len(arg.Choices) > 0 {
-			// Ensure options contains value
-			found := false
+	if len(errs) > 0 {
+		return errs
+	}
 
-			for _, value := range arg.Choices {
-				if value.Value == argValue {
-					found = true
-					break
-				}
-			}
+	return nil
+}
+
+// validateArgument validates a single argument value, storing its typed value on the context
+func validateArgument(ctx *Context, arg *Argument, argValue string) error {
+	var err error
+
+	// Interactions provide typed values, which only need their limits checked.
+	value, exists := ctx.values[arg.Name]
 
-			if !found {
-				return InvalidValueError{Argument: arg.Name, Value: argValue}
+	if exists {
+		err = checkValue(ctx, arg, value)
+	} else {
+		value, err = parseArgument(ctx, arg, argValue)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(arg.Choices) > 0 {
+		// Ensure options contains value
+		found := false
+
+		for _, choice := range arg.Choices {
+			if choice.Value == argValue {
+				found = true
+				break
 			}
 		}
+
+		if !found {
+			return InvalidValueError{Argument: arg.Name, Value: argValue}
+		}
+	}
+
+	ctx.setValue(arg.Name, value)
+
+	return nil
+}
+
+// sortedArguments returns the route's arguments in signature order
+func (r *Route) sortedArguments() []*Argument {
+	args := make([]*Argument, 0, len(r.Arguments))
+
+	for _, arg := range r.Arguments {
+		args = append(args, arg)
+	}
+
+	sort.Slice(args, func(i, j int) bool {
+		return args[i].position < args[j].position
+	})
+
+	return args
+}
+
+// parseArgument validates an argument value and converts it to the argument type's value
+func parseArgument(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
+	switch arg.Type {
+	case ArgumentTypeInt:
+		return validateInt(ctx, arg, argValue)
+	case ArgumentTypeFloat:
+		return validateFloat(ctx, arg, argValue)
+	case ArgumentTypeBool:
+		return validateBool(ctx, arg, argValue)
+	case ArgumentTypeEmoji:
+		return validateEmoji(ctx, arg, argValue)
+	case ArgumentTypeUserMention:
+		return validateUserMention(ctx, arg, argValue)
+	case ArgumentTypeChannelMention:
+		return validateChannelMention(ctx, arg, argValue)
+	case ArgumentTypeRole:
+		return validateRoleMention(ctx, arg, argValue)
+	case ArgumentTypeMentionable:
+		return validateMentionable(ctx, arg, argValue)
+	}
+
+	return argValue, checkLength(ctx, arg, argValue)
+}
+
+// checkValue checks an already typed value against the argument's limits
+func checkValue(ctx *Context, arg *Argument, value interface{}) error {
+	switch v := value.(type) {
+	case int64:
+		return checkInt(ctx, arg, v)
+	case float64:
+		return checkFloat(ctx, arg, v)
+	case string:
+		return checkLength(ctx, arg, v)
+	case discord.Channel:
+		return checkChannelType(ctx, arg, v)
 	}
 
 	return nil
 }
 
 // validateInt checks an integer argument to ensure it's a valid integer
-func validateInt(ctx *Context, arg *Argument, argValue string) error {
+func validateInt(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
 	v, err := strconv.ParseInt(argValue, 10, 64)
 
 	if err != nil {
-		return fmt.Errorf("%s must be an integer.", arg.Name)
+		return nil, ctx.translatedError(MessageInteger, arg.Name)
 	}
 
+	return v, checkInt(ctx, arg, v)
+}
+
+// checkInt checks an integer against the argument's inclusive min and max
+func checkInt(ctx *Context, arg *Argument, v int64) error {
 	if arg.Min != nil && v < arg.Min.(int64) {
-		return fmt.Errorf("%s must be larger than %d.", arg.Name, arg.Min)
+		return ctx.translatedError(MessageMinInt, arg.Name, arg.Min)
 	}
 
-	if arg.Max != nil && v < arg.Max.(int64) {
-		return fmt.Errorf("%s must be smaller than %d.", arg.Name, arg.Max)
+	if arg.Max != nil && v > arg.Max.(int64) {
+		return ctx.translatedError(MessageMaxInt, arg.Name, arg.Max)
 	}
 
 	return nil
 }
 
 // validateFloat checks an integer argument to ensure it's a valid float
-func validateFloat(ctx *Context, arg *Argument, argValue string) error {
+func validateFloat(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
 	v, err := strconv.ParseFloat(argValue, 64)
 
 	if err != nil {
-		return fmt.Errorf("%s must be a floating point number.", arg.Name)
+		return nil, ctx.translatedError(MessageFloat, arg.Name)
 	}
 
+	return v, checkFloat(ctx, arg, v)
+}
+
+// checkFloat checks a float against the argument's inclusive min and max
+func checkFloat(ctx *Context, arg *Argument, v float64) error {
 	if arg.Min != nil && v < arg.Min.(float64) {
-		return fmt.Errorf("%s must be larger than %f.", arg.Name, arg.Min)
+		return ctx.translatedError(MessageMinFloat, arg.Name, arg.Min)
 	}
 
-	if arg.Max != nil && v < arg.Max.(float64) {
-		return fmt.Errorf("%s must be smaller than %f.", arg.Name, arg.Max)
+	if arg.Max != nil && v > arg.Max.(float64) {
+		return ctx.translatedError(MessageMaxFloat, arg.Name, arg.Max)
+	}
+
+	return nil
+}
+
+// checkLength checks a string against the argument's minimum and maximum length
+func checkLength(ctx *Context, arg *Argument, v string) error {
+	length := utf8.RuneCountInString(v)
+
+	if arg.MinLength > 0 && length < arg.MinLength {
+		return ctx.translatedError(MessageMinLength, arg.Name, arg.MinLength)
+	}
+
+	if arg.MaxLength > 0 && length > arg.MaxLength {
+		return ctx.translatedError(MessageMaxLength, arg.Name, arg.MaxLength)
 	}
 
 	return nil
 }
 
 // validateBool checks an integer argument to ensure it's a valid bool
-func validateBool(ctx *Context, arg *Argument, argValue string) error {
-	_, err := strconv.ParseBool(argValue)
+func validateBool(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
+	v, err := strconv.ParseBool(argValue)
 
 	if err != nil {
-		return fmt.Errorf("%s must be a true/false value.", arg.Name)
+		return nil, ctx.translatedError(MessageBool, arg.Name)
 	}
 
-	return nil
+	return v, nil
 }
 
 // validateEmoji checks an integer argument to ensure it's a valid bool
-func validateEmoji(ctx *Context, arg *Argument, argValue string) error {
-	if emojiRegexp.MatchString(argValue) {
-		return nil
+func validateEmoji(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
+	if m := emojiRegexp.FindStringSubmatch(argValue); m != nil {
+		sf, err := discord.ParseSnowflake(m[3])
+
+		if err == nil {
+			return discord.Emoji{
+				ID:       discord.EmojiID(sf),
+				Name:     m[2],
+				Animated: m[1] == "a",
+			}, nil
+		}
 	}
 
-	_, err := emoji.LookupEmoji(argValue)
+	result, err := emoji.LookupEmoji(argValue)
 
 	if err == nil {
-		return nil
+		return discord.Emoji{Name: result.Value}, nil
 	}
 
-	return fmt.Errorf("%s must be a valid emoji.", arg.Name)
+	return nil, ctx.translatedError(MessageEmoji, arg.Name)
 }
 
 // validateUserMention checks a user mention argument to ensure the user exists
-func validateUserMention(ctx *Context, arg *Argument, argValue string) error {
+func validateUserMention(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
 	m := userMentionRegexp.FindStringSubmatch(argValue)
 
 	if m == nil {
-		return fmt.Errorf("%s must be a valid user.", arg.Name)
+		return nil, ctx.translatedError(MessageUser, arg.Name)
 	}
 
 	sf, err := discord.ParseSnowflake(m[1])
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	member, err := ctx.Session.Member(ctx.Guild.ID, discord.UserID(sf))
+	id := discord.UserID(sf)
 
-	if member != nil && err == nil {
-		return nil
+	if ctx.Guild == nil {
+		// Outside of guilds, any valid user can be used.
+		u, err := ctx.Session.User(id)
+
+		if u != nil && err == nil {
+			return *u, nil
+		}
+	} else {
+		member, err := ctx.Session.Member(ctx.Guild.ID, id)
+
+		if member != nil && err == nil {
+			return member.User, nil
+		}
 	}
 
 	// User is not in this guild/doesn't exist.
-	return fmt.Errorf("%s must be a valid user.", arg.Name)
+	return nil, ctx.translatedError(MessageUser, arg.Name)
 }
 
 // validateChannelMention checks a channel mention argument to ensure the channel exists
-func validateChannelMention(ctx *Context, arg *Argument, argValue string) error {
+func validateChannelMention(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
 	m := channelMentionRegexp.FindStringSubmatch(argValue)
 
 	if m == nil {
-		return fmt.Errorf("%s must be a valid channel.", arg.Name)
+		return nil, ctx.translatedError(MessageChannel, arg.Name)
 	}
 
 	sf, err := discord.ParseSnowflake(m[1])
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	c, err := ctx.Session.Channel(discord.ChannelID(sf))
 
-	if c != nil && c.GuildID == ctx.Guild.ID {
-		return nil
+	if c != nil && err == nil && ctx.Guild != nil && c.GuildID == ctx.Guild.ID {
+		return *c, checkChannelType(ctx, arg, *c)
 	}
 
 	// Channel does not exist, or is not in this guild.
-	return fmt.Errorf("%s must be a valid channel.", arg.Name)
+	return nil, ctx.translatedError(MessageChannel, arg.Name)
+}
+
+// checkChannelType checks a channel against the argument's allowed channel types
+func checkChannelType(ctx *Context, arg *Argument, c discord.Channel) error {
+	if len(arg.ChannelTypes) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(arg.ChannelTypes))
+
+	for _, t := range arg.ChannelTypes {
+		if c.Type == t {
+			return nil
+		}
+
+		for name, nameType := range channelTypeNames {
+			if nameType == t {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return ctx.translatedError(MessageChannelType, arg.Name, strings.Join(names, " or "))
+}
+
+// validateRoleMention checks a role mention argument to ensure the role exists in the guild
+func validateRoleMention(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
+	m := roleMentionRegexp.FindStringSubmatch(argValue)
+
+	if m == nil || ctx.Guild == nil {
+		return nil, ctx.translatedError(MessageRole, arg.Name)
+	}
+
+	sf, err := discord.ParseSnowflake(m[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := ctx.Session.Role(ctx.Guild.ID, discord.RoleID(sf))
+
+	if role != nil && err == nil {
+		return *role, nil
+	}
+
+	return nil, ctx.translatedError(MessageRole, arg.Name)
+}
+
+// validateMentionable checks a mentionable argument, which can be either a user or a role
+func validateMentionable(ctx *Context, arg *Argument, argValue string) (interface{}, error) {
+	if roleMentionRegexp.MatchString(argValue) {
+		if v, err := validateRoleMention(ctx, arg, argValue); err == nil {
+			role := v.(discord.Role)
+			return Mentionable{ID: discord.Snowflake(role.ID), Role: &role}, nil
+		}
+	} else if v, err := validateUserMention(ctx, arg, argValue); err == nil {
+		user := v.(discord.User)
+		return Mentionable{ID: discord.Snowflake(user.ID), User: &user}, nil
+	}
+
+	return nil, ctx.translatedError(MessageMentionable, arg.Name)
+}
+
+// validateAttachment checks an attachment argument.
+// Interactions provide attachments as typed values, messages use their attachments in the order of the arguments.
+func validateAttachment(ctx *Context, arg *Argument) error {
+	if _, exists := ctx.values[arg.Name]; exists {
+		return nil
+	}
+
+	if arg.Index < len(ctx.Message.Attachments) {
+		ctx.setValue(arg.Name, ctx.Message.Attachments[arg.Index])
+		return nil
+	}
+
+	if arg.Required {
+		return ctx.translatedError(MessageAttachmentRequired, arg.Name)
+	}
+
+	return nil
 }